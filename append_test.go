@@ -0,0 +1,85 @@
+package mylog
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var sharedRecord = regexp.MustCompile(`^w(\d) \[INFO\]  (\d+) x+$`)
+
+func TestSharedAppendLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared.log")
+	const writers, records = 4, 200
+	padding := strings.Repeat("x", 200)
+
+	var wg sync.WaitGroup
+	for w := range writers {
+		// Each logger opens the file itself, as a separate process would.
+		l, err := NewSharedAppendLogger(path, fmt.Sprintf("w%d", w), 0, INFO)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := l.Writer().(*SharedAppendWriter); !ok {
+			t.Fatalf("Writer is %T", l.Writer())
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer l.Close()
+			for i := range records {
+				l.Info(i, padding)
+			}
+		}()
+	}
+	wg.Wait()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	next := make([]int, writers)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		m := sharedRecord.FindStringSubmatch(sc.Text())
+		if m == nil {
+			t.Fatalf("interleaved record %q", sc.Text())
+		}
+		var w, i int
+		fmt.Sscan(m[1], &w)
+		fmt.Sscan(m[2], &i)
+		if i != next[w] {
+			t.Fatalf("writer %d: record %d, want %d", w, i, next[w])
+		}
+		next[w]++
+	}
+	for w, n := range next {
+		if n != records {
+			t.Errorf("writer %d: %d records, want %d", w, n, records)
+		}
+	}
+}
+
+func TestSharedAppendWriterWarnsOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "big.log")
+	l, err := NewSharedAppendLogger(path, "", 0, INFO)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	big := strings.Repeat("x", AtomicWriteSize)
+	stderr := captureStderr(t, func() {
+		l.Info("small")
+		l.Info(big)
+		l.Info(big)
+	})
+	if n := strings.Count(stderr, "exceeds the atomic append size"); n != 1 {
+		t.Errorf("%d warnings, want 1: %q", n, stderr)
+	}
+}
@@ -0,0 +1,106 @@
+package mylog
+
+import (
+	"io"
+	"slices"
+	"time"
+)
+
+// Batch collects records that are written together by Commit.
+type Batch struct {
+	l     *Logger
+	lines []batchLine
+}
+
+type batchLine struct {
+	level  Level
+	line   []byte
+	msg    []byte
+	routes []io.Writer
+
+	// time, mirror and fields are kept for the slog mirror.
+	time   time.Time
+	mirror []byte
+	fields []field
+}
+
+// Batch returns a builder whose records are written contiguously, in a
+// single Write, when Commit is called.
+func (l *Logger) Batch() *Batch {
+	return &Batch{l: l}
+}
+
+// Add formats a record for the batch. Each record is filtered on its own,
+// as a regular log call would be, at the time Add is called; the record's
+// timestamp and caller are also taken then.
+func (b *Batch) Add(level Level, v ...any) {
+	now, ok := b.l.admit(level)
+	if !ok {
+		return
+	}
+	buf := getBuffer()
+	defer putBuffer(buf, b.l.maxBufferReuse())
+	scratch := getBuffer()
+	defer putBuffer(scratch, b.l.maxBufferReuse())
+	e := getEntry(now)
+	defer putEntry(e)
+	msg, hashable := b.l.format(buf, scratch, e, level, 0, 2, func(p []byte, e *entry) []byte {
+		return b.l.appendArgs(p, e, v)
+	})
+	bl := batchLine{level: level, line: slices.Clone(*buf), routes: b.l.matchRoutes(e.fields)}
+	if hashable {
+		bl.msg = slices.Clone(msg)
+	}
+	if b.l.slogMirror.Load() {
+		bl.time = now
+		bl.mirror = slices.Clone(mirrorMessage(msg, e.spans))
+		bl.fields = slices.Clone(e.fields)
+	}
+	b.lines = append(b.lines, bl)
+}
+
+// Commit writes the batch's records in one Write and empties the batch. A
+// LevelWriter output receives the whole batch at the highest level in it.
+func (b *Batch) Commit() error {
+	if len(b.lines) == 0 {
+		return nil
+	}
+	l := b.l
+	buf := getBuffer()
+	defer putBuffer(buf, l.maxBufferReuse())
+
+	mode := l.integrity.Load()
+	if mode == integrityChained {
+		l.hashMu.Lock()
+		defer l.hashMu.Unlock()
+	}
+	r := heldRecord{level: b.lines[0].level}
+	ends := make([]int, len(b.lines))
+	for i, bl := range b.lines {
+		r.level = max(r.level, bl.level)
+		r.counts[min(bl.level, ERROR+1)]++
+		*buf = append(*buf, bl.line...)
+		if mode != integrityOff && bl.msg != nil {
+			l.appendIntegrity(buf, bl.msg, mode == integrityChained)
+			*buf = append(*buf, '\n')
+		}
+		ends[i] = len(*buf)
+	}
+	r.p = *buf
+	err := l.send(r)
+	start := 0
+	for i, bl := range b.lines {
+		if bl.routes != nil {
+			l.send(heldRecord{level: bl.level, p: (*buf)[start:ends[i]], routes: bl.routes, routeOnly: true})
+		}
+		start = ends[i]
+	}
+	for _, bl := range b.lines {
+		l.notifyLevel(bl.level, bl.line)
+		if !bl.time.IsZero() {
+			l.mirrorToSlog(bl.level, bl.time, bl.mirror, bl.fields)
+		}
+	}
+	b.lines = b.lines[:0]
+	return err
+}
@@ -0,0 +1,63 @@
+package mylog
+
+import "testing"
+
+type levelRecorder struct {
+	writes []string
+	levels []Level
+}
+
+func (w *levelRecorder) Write(p []byte) (int, error) {
+	return w.WriteLevel(INFO, p)
+}
+
+func (w *levelRecorder) WriteLevel(level Level, p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	w.levels = append(w.levels, level)
+	return len(p), nil
+}
+
+func TestBatch(t *testing.T) {
+	var w levelRecorder
+	l := New(&w, "", 0, INFO)
+	b := l.Batch()
+	if err := b.Commit(); err != nil || len(w.writes) != 0 {
+		t.Fatalf("empty Commit = %v, wrote %q", err, w.writes)
+	}
+	b.Add(INFO, "first")
+	b.Add(DEBUG, "filtered")
+	b.Add(ERROR, "second")
+	b.Add(INFO, "third")
+	if len(w.writes) != 0 {
+		t.Fatalf("written before Commit: %q", w.writes)
+	}
+	if err := b.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	want := "[INFO]  first\n[ERROR] second\n[INFO]  third\n"
+	if len(w.writes) != 1 || w.writes[0] != want || w.levels[0] != ERROR {
+		t.Errorf("writes = %q at %v, want one %q at ERROR", w.writes, w.levels, want)
+	}
+
+	b.Add(INFO, "next")
+	b.Commit()
+	if len(w.writes) != 2 || w.writes[1] != "[INFO]  next\n" {
+		t.Errorf("Commit did not empty the batch: %q", w.writes)
+	}
+}
+
+func TestBatchIntegrity(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetIntegrityHashing(true)
+	b := l.Batch()
+	b.Add(INFO, "a")
+	b.Add(INFO, "b")
+	b.Commit()
+	h1 := shortHash("00000000a")
+	h2 := shortHash(h1 + "b")
+	want := "[INFO]  a hash=" + h1 + " prev_hash=00000000\n" +
+		"[INFO]  b hash=" + h2 + " prev_hash=" + h1 + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,41 @@
+package mylog
+
+import "runtime"
+
+// maxFilteredFrames bounds how far SetCallerFilter walks up the stack.
+const maxFilteredFrames = 32
+
+// SetCallerFilter makes Lshortfile, Llongfile and Lpackage report the first
+// frame, starting from the logging call site and walking outwards, for which
+// keep returns true, so that frames inside a framework that wraps the logger
+// can be skipped. If no frame within 32 of the call site is kept, the call
+// site is reported. A nil keep removes the filter.
+func (l *Logger) SetCallerFilter(keep func(frame runtime.Frame) bool) {
+	if keep == nil {
+		l.callerFilter.Store(nil)
+		return
+	}
+	l.callerFilter.Store(&keep)
+}
+
+// filteredCaller returns the frame runtime.Caller(calldepth) would report,
+// or the first frame above it kept by the caller filter.
+func (l *Logger) filteredCaller(calldepth int) (f runtime.Frame) {
+	keep := l.callerFilter.Load()
+	var pcs [maxFilteredFrames]uintptr
+	n := runtime.Callers(calldepth+2, pcs[:])
+	if n == 0 {
+		return f
+	}
+	frames := runtime.CallersFrames(pcs[:n])
+	first, more := frames.Next()
+	f = first
+	for keep != nil && !(*keep)(f) {
+		if !more {
+			f = first
+			break
+		}
+		f, more = frames.Next()
+	}
+	return f
+}
@@ -0,0 +1,35 @@
+package mylog
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// frameworkLog stands in for a framework helper wrapping the logger. It
+// returns the line of its log call.
+func frameworkLog(l *Logger, msg string) int {
+	line := thisLine() + 1
+	l.Info(msg)
+	return line
+}
+
+func TestCallerFilter(t *testing.T) {
+	l, buf := newTestLogger(Lshortfile)
+	l.SetCallerFilter(func(f runtime.Frame) bool {
+		return !strings.HasSuffix(f.Function, ".frameworkLog")
+	})
+	line := thisLine() + 1
+	frameworkLog(l, "filtered")
+	l.SetCallerFilter(func(runtime.Frame) bool { return false })
+	inner := frameworkLog(l, "nothing kept")
+	l.SetCallerFilter(nil)
+	frameworkLog(l, "unfiltered")
+	want := fmt.Sprintf("[INFO]  callerfilter_test.go:%d: filtered\n", line) +
+		fmt.Sprintf("[INFO]  callerfilter_test.go:%d: nothing kept\n", inner) +
+		fmt.Sprintf("[INFO]  callerfilter_test.go:%d: unfiltered\n", inner)
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
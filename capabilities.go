@@ -0,0 +1,32 @@
+package mylog
+
+import (
+	"io"
+	"time"
+)
+
+// WriterCapabilities reports which optional interfaces a writer implements.
+type WriterCapabilities struct {
+	Syncer      bool // Sync() error, as on *os.File
+	Closer      bool // io.Closer
+	Reopener    bool // Reopen() error, as on rotating file writers
+	LevelWriter bool // LevelWriter
+	Deadliner   bool // SetWriteDeadline(time.Time) error, as on net.Conn
+}
+
+// Capabilities reports the optional interfaces implemented by the current
+// output, such as one set with SetOutput or returned by the SetWriterFunc
+// function. Writers added with AddTempOutput are not included.
+func (l *Logger) Capabilities() WriterCapabilities {
+	l.outMu.Lock()
+	w := l.writer()
+	l.outMu.Unlock()
+
+	var c WriterCapabilities
+	_, c.Syncer = w.(interface{ Sync() error })
+	_, c.Closer = w.(io.Closer)
+	_, c.Reopener = w.(interface{ Reopen() error })
+	_, c.LevelWriter = w.(LevelWriter)
+	_, c.Deadliner = w.(interface{ SetWriteDeadline(time.Time) error })
+	return c
+}
@@ -0,0 +1,44 @@
+package mylog
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "cap.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	c1, c2 := net.Pipe()
+	defer c1.Close()
+	defer c2.Close()
+
+	tests := []struct {
+		name string
+		w    io.Writer
+		want WriterCapabilities
+	}{
+		{"buffer", &bytes.Buffer{}, WriterCapabilities{}},
+		{"file", f, WriterCapabilities{Syncer: true, Closer: true, Deadliner: true}},
+		{"conn", c1, WriterCapabilities{Closer: true, Deadliner: true}},
+		{"split", &SplitFileWriter{}, WriterCapabilities{Closer: true, LevelWriter: true}},
+	}
+	for _, tt := range tests {
+		l := New(tt.w, "", 0, INFO)
+		if got := l.Capabilities(); got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	l := New(&bytes.Buffer{}, "", 0, INFO)
+	l.SetWriterFunc(func() io.Writer { return f })
+	if got := l.Capabilities(); !got.Syncer {
+		t.Errorf("SetWriterFunc output: got %+v", got)
+	}
+}
@@ -0,0 +1,65 @@
+package mylog
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// InfoChanged logs v at INFO only when value differs from the value last
+// logged for key, with a key=old->new field (or key=new the first time).
+// Comparable values are compared with ==; others, such as slices and maps,
+// with reflect.DeepEqual. A value counts as logged only once its record
+// passes the level filter, rate cap and other checks, so a change dropped
+// by them is logged again on the next call. Calls are serialized, so
+// concurrent calls with the same value log one change between them.
+func (l *Logger) InfoChanged(key string, value any, v ...any) {
+	if !l.enabled(INFO) {
+		return
+	}
+
+	l.changedMu.Lock()
+	defer l.changedMu.Unlock()
+	old, seen := l.changed[key]
+	if seen && valuesEqual(old, value) {
+		return
+	}
+
+	l.output(INFO, 0, 2, func(b []byte, e *entry) []byte {
+		// The record has been admitted: commit the value.
+		if l.changed == nil {
+			l.changed = make(map[string]any)
+		}
+		l.changed[key] = value
+
+		b = l.appendArgs(b, e, v)
+		b = l.appendFields(b[:len(b)-1], e, []any{key, changedValue{old, value, seen}})
+		return append(b, '\n')
+	})
+}
+
+// changedValue is the field value of an InfoChanged record.
+type changedValue struct {
+	old, new any
+	seen     bool
+}
+
+func (c changedValue) String() string {
+	if !c.seen {
+		return fmt.Sprint(c.new)
+	}
+	return fmt.Sprint(c.old) + "->" + fmt.Sprint(c.new)
+}
+
+func valuesEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() {
+		return false
+	}
+	if va.Comparable() && vb.Comparable() {
+		return a == b
+	}
+	return reflect.DeepEqual(a, b)
+}
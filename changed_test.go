@@ -0,0 +1,81 @@
+package mylog
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestInfoChanged(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.InfoChanged("k", 1)
+	l.InfoChanged("k", 1)
+	l.InfoChanged("k", 2)
+	l.InfoChanged("k", 3, "temperature")
+	l.InfoChanged("other", "a b")
+	l.InfoChanged("list", []int{1})
+	l.InfoChanged("list", []int{1})
+	want := "[INFO]  k=1\n" +
+		"[INFO]  k=1->2\n" +
+		"[INFO]  temperature k=2->3\n" +
+		"[INFO]  other=\"a b\"\n" +
+		"[INFO]  list=[1]\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestInfoChangedDropped(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetMaxLinesPerSecond(1)
+	l.InfoChanged("k", 1)
+	l.InfoChanged("k", 2)
+	l.SetMaxLinesPerSecond(0)
+	l.InfoChanged("k", 2)
+
+	l.SetLevel(ERROR)
+	l.InfoChanged("k", 3)
+	l.SetLevel(INFO)
+	l.InfoChanged("k", 3)
+	want := "[INFO]  k=1\n[INFO]  k=1->2\n[INFO]  k=2->3\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestInfoChangedMasked(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetSensitiveKeys(MatchExact, "token")
+	l.InfoChanged("token", "abc")
+	l.InfoChanged("token", "def")
+	if got, want := buf.String(), "[INFO]  token=***\n[INFO]  token=***\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestInfoChangedConcurrent(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.InfoChanged("k", 1)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.InfoChanged("k", 5)
+		}()
+	}
+	wg.Wait()
+	if got, want := buf.String(), "[INFO]  k=1\n[INFO]  k=1->5\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestInfoChangedCSV(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetHeaderStyle(HeaderCSV)
+	l.InfoChanged("k", 1, "temperature")
+	l.InfoChanged("k", 2)
+	want := ",INFO,,,temperature,k=1\n,INFO,,,,k=1->2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package mylog
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+type coarseClock struct {
+	now  atomic.Pointer[time.Time]
+	stop chan struct{}
+}
+
+func newCoarseClock(resolution time.Duration) *coarseClock {
+	c := &coarseClock{stop: make(chan struct{})}
+	now := time.Now()
+	c.now.Store(&now)
+	go func() {
+		t := time.NewTicker(resolution)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				now := time.Now()
+				c.now.Store(&now)
+			case <-c.stop:
+				return
+			}
+		}
+	}()
+	return c
+}
+
+// SetCoarseClock makes the logger timestamp records with a cached time that
+// a background goroutine refreshes every resolution, instead of calling
+// time.Now for each record. Timestamps may then lag by up to resolution,
+// and records within one tick share a time. A resolution <= 0 returns to
+// time.Now and stops the goroutine, as does Close.
+func (l *Logger) SetCoarseClock(resolution time.Duration) {
+	var c *coarseClock
+	if resolution > 0 {
+		c = newCoarseClock(resolution)
+	}
+	if old := l.coarse.Swap(c); old != nil {
+		close(old.stop)
+	}
+}
+
+// SetClock makes the logger read record timestamps, and the times used by
+// Begin, End and InfoTTL, from now instead of the system clock, for example
+// to get fixed times in tests. It takes precedence over SetCoarseClock. A
+// nil now restores the default.
+func (l *Logger) SetClock(now func() time.Time) {
+	if now == nil {
+		l.clock.Store(nil)
+		return
+	}
+	l.clock.Store(&now)
+}
+
+func (l *Logger) now() time.Time {
+	if now := l.clock.Load(); now != nil {
+		return (*now)()
+	}
+	if c := l.coarse.Load(); c != nil {
+		return *c.now.Load()
+	}
+	return time.Now()
+}
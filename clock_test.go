@@ -0,0 +1,71 @@
+package mylog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoarseClock(t *testing.T) {
+	l := New(nopWriter{}, "", 0, INFO)
+	defer l.Close()
+	l.SetCoarseClock(time.Hour)
+	t1 := l.now()
+	time.Sleep(2 * time.Millisecond)
+	if t2 := l.now(); !t2.Equal(t1) {
+		t.Errorf("coarse clock moved within a tick: %v, %v", t1, t2)
+	}
+
+	l.SetCoarseClock(time.Millisecond)
+	t1 = l.now()
+	waitFor(t, func() bool { return l.now().After(t1) })
+
+	l.SetCoarseClock(0)
+	if l.coarse.Load() != nil {
+		t.Error("SetCoarseClock(0) kept the coarse clock")
+	}
+	t1 = l.now()
+	time.Sleep(time.Millisecond)
+	if !l.now().After(t1) {
+		t.Error("time.Now not restored")
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	l := New(nopWriter{}, "", 0, INFO)
+	defer l.Close()
+	l.SetCoarseClock(time.Hour)
+	l.SetClock(func() time.Time { return testTime })
+	if got := l.now(); !got.Equal(testTime) {
+		t.Errorf("SetClock does not take precedence: %v", got)
+	}
+	l.SetClock(nil)
+	if got := l.now(); got.Equal(testTime) {
+		t.Error("SetClock(nil) kept the clock")
+	}
+}
+
+func TestCloseStopsCoarseClock(t *testing.T) {
+	l := New(nopWriter{}, "", 0, INFO)
+	l.SetCoarseClock(time.Millisecond)
+	l.Close()
+	if l.coarse.Load() != nil {
+		t.Error("Close kept the coarse clock")
+	}
+}
+
+func BenchmarkClock(b *testing.B) {
+	b.Run("time.Now", func(b *testing.B) {
+		l := New(nopWriter{}, "", LstdFlags, INFO)
+		for b.Loop() {
+			l.Info("tick")
+		}
+	})
+	b.Run("coarse", func(b *testing.B) {
+		l := New(nopWriter{}, "", LstdFlags, INFO)
+		l.SetCoarseClock(10 * time.Millisecond)
+		defer l.SetCoarseClock(0)
+		for b.Loop() {
+			l.Info("tick")
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package mylog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"slices"
+)
+
+// Close flushes the output if it has a Flush method and closes it if it
+// implements io.Closer, followed by any writers registered with
+// SetCloseChain; os.Stdout and os.Stderr are never closed. Records
+// held by Pause are written first, and records logged after Close are
+// discarded. Calls after the first return nil.
+func (l *Logger) Close() error {
+	// Held records are written before the summary so that it counts them
+	// and is not itself held.
+	resumeErr := l.Resume()
+	l.emitSummary()
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	if l.closed {
+		return nil
+	}
+	errs := []error{resumeErr}
+	l.closed = true
+	l.updateDiscard()
+	l.SetCoarseClock(0)
+	if l.done != nil {
+		close(l.done)
+	}
+
+	ws := append([]io.Writer{l.writer()}, l.chain...)
+	for _, w := range ws {
+		if f, ok := w.(interface{ Flush() error }); ok {
+			errs = append(errs, f.Flush())
+		}
+	}
+	for _, w := range ws {
+		if c, ok := w.(io.Closer); ok && w != os.Stdout && w != os.Stderr {
+			if err := c.Close(); !errors.Is(err, os.ErrClosed) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SetCloseChain registers the writers underneath the output, such as a
+// GzipWriter and the file it writes to beneath a buffering output, ordered
+// from the one the output writes to down to the innermost. Close flushes
+// the output and then each of ws in that order before closing them in the
+// same order, so data buffered in an outer layer reaches the file. A writer
+// already closed by the layer above it is not reported as an error.
+func (l *Logger) SetCloseChain(ws ...io.Writer) {
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	l.chain = slices.Clone(ws)
+}
+
+func (l *Logger) closedChan() <-chan struct{} {
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	if l.done == nil {
+		l.done = make(chan struct{})
+		if l.closed {
+			close(l.done)
+		}
+	}
+	return l.done
+}
+
+// WatchContext closes the logger, flushing its output, once ctx is done.
+// The watching goroutine also exits if the logger is closed first.
+func (l *Logger) WatchContext(ctx context.Context) {
+	done := l.closedChan()
+	go func() {
+		select {
+		case <-ctx.Done():
+			l.Close()
+		case <-done:
+		}
+	}()
+}
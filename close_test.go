@@ -0,0 +1,72 @@
+package mylog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type recordingWriter struct {
+	name  string
+	calls *[]string
+	err   error
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+func (w *recordingWriter) Flush() error {
+	*w.calls = append(*w.calls, "flush "+w.name)
+	return nil
+}
+
+func (w *recordingWriter) Close() error {
+	*w.calls = append(*w.calls, "close "+w.name)
+	return w.err
+}
+
+func TestClose(t *testing.T) {
+	var calls []string
+	out := &recordingWriter{name: "out", calls: &calls}
+	l := New(out, "", 0, DEBUG)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("second Close = %v", err)
+	}
+	if got, want := strings.Join(calls, ", "), "flush out, close out"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	if got := l.Explain(INFO); got != "suppressed: logger is closed" {
+		t.Errorf("Explain after Close = %q", got)
+	}
+}
+
+func TestCloseChain(t *testing.T) {
+	var calls []string
+	out := &recordingWriter{name: "buffer", calls: &calls}
+	gz := &recordingWriter{name: "gzip", calls: &calls}
+	file := &recordingWriter{name: "file", calls: &calls, err: fmt.Errorf("close file: %w", errClosedTest)}
+	l := New(out, "", 0, DEBUG)
+	l.SetCloseChain(gz, file)
+	err := l.Close()
+	want := "flush buffer, flush gzip, flush file, close buffer, close gzip, close file"
+	if got := strings.Join(calls, ", "); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	if !errors.Is(err, errClosedTest) {
+		t.Errorf("Close = %v, want the file's error", err)
+	}
+}
+
+var errClosedTest = errors.New("test close error")
+
+func TestWatchContext(t *testing.T) {
+	l, _ := newTestLogger(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	l.WatchContext(ctx)
+	cancel()
+	waitFor(t, func() bool { return l.Explain(INFO) == "suppressed: logger is closed" })
+}
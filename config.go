@@ -0,0 +1,138 @@
+package mylog
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+func formatFlags(flag int) string {
+	var names []string
+	for _, f := range flagNames {
+		if flag&f.flag != 0 {
+			names = append(names, f.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ",")
+}
+
+var styleNames = [...]string{
+	HeaderPositional: "text",
+	HeaderKeyValue:   "logfmt",
+	HeaderCSV:        "csv",
+}
+
+// LogConfig logs the logger's current configuration at level as
+// config key=value pairs, for inclusion in bug reports. The pairs are
+// message text rather than fields, so SetAllowedFieldKeys,
+// SetSensitiveKeys and SetOmitEmptyFields do not change them.
+func (l *Logger) LogConfig(level Level) {
+	kv := []any{"level", levelName(l.Level())}
+	if f := l.levelFilter.Load(); f != nil {
+		var allowed []string
+		for level, ok := range f {
+			if ok {
+				allowed = append(allowed, levelName(Level(level)))
+			}
+		}
+		kv = append(kv, "filter", strings.Join(allowed, ","))
+	}
+	if !debugBuild {
+		kv = append(kv, "debug", "compiled_out")
+	}
+	kv = append(kv, "flags", formatFlags(l.Flags()))
+	if m := l.levelFlags.Load(); m != nil {
+		for _, level := range slices.Sorted(maps.Keys(*m)) {
+			kv = append(kv, "flags."+levelName(level), formatFlags((*m)[level]))
+		}
+	}
+	style := "unknown"
+	if s := HeaderStyle(l.headerStyle.Load()); int(s) < len(styleNames) {
+		style = styleNames[s]
+	}
+	kv = append(kv, "format", style, "prefix", l.Prefix())
+
+	l.outMu.Lock()
+	if l.outFunc != nil {
+		kv = append(kv, "output", "func")
+	} else {
+		kv = append(kv, "output", fmt.Sprintf("%T", l.out))
+	}
+	kv = append(kv, "temp_outputs", len(l.temp), "paused", l.paused, "closed", l.closed)
+	if l.paused {
+		kv = append(kv, "held", len(l.pending))
+	}
+	if l.pauseLimit > 0 {
+		kv = append(kv, "pause_limit", l.pauseLimit)
+	}
+	l.outMu.Unlock()
+
+	if lim := l.limiter.Load(); lim != nil {
+		kv = append(kv, "max_lines_per_second", lim.rate)
+	}
+	if l.burst.Load() != nil {
+		kv = append(kv, "burst_alert", true)
+	}
+	switch l.integrity.Load() {
+	case integrityHash:
+		kv = append(kv, "integrity", "hash")
+	case integrityChained:
+		kv = append(kv, "integrity", "chained")
+	}
+	if loc := l.loc.Load(); loc != nil {
+		kv = append(kv, "time_zone", loc.String())
+	}
+	if l.coarse.Load() != nil {
+		kv = append(kv, "coarse_clock", true)
+	}
+	if s := l.siteLimit.Load(); s != nil {
+		kv = append(kv, "caller_site_limit", fmt.Sprintf("%d/%s", s.n, s.window))
+	}
+	if a := l.allowedKeys.Load(); a != nil {
+		mode := "warn"
+		if a.mode == FieldKeysReject {
+			mode = "reject"
+		}
+		kv = append(kv, "allowed_keys", mode+":"+strings.Join(slices.Sorted(maps.Keys(a.keys)), ","))
+	}
+	if s := l.sensitive.Load(); s != nil {
+		match := "exact"
+		if s.match == MatchContains {
+			match = "contains"
+		}
+		kv = append(kv, "sensitive_keys", match+":"+strings.Join(s.keys, ","))
+	}
+	if v := l.verbosity.Load(); v != 0 {
+		kv = append(kv, "verbosity", v)
+	}
+	if env := l.environment(); env != "" {
+		kv = append(kv, "env", env)
+	}
+	if p := l.schema.Load(); p != nil {
+		kv = append(kv, "schema", *p)
+	}
+	if l.numericLevel.Load() {
+		kv = append(kv, "numeric_level", true)
+	}
+	if l.slogMirror.Load() {
+		kv = append(kv, "slog_mirror", true)
+	}
+	if o := l.offload.Load(); o != nil {
+		kv = append(kv, "field_offload", o.threshold)
+	}
+
+	l.output(level, 0, 2, func(b []byte, e *entry) []byte {
+		b = append(b, "config"...)
+		for i := 0; i < len(kv); i += 2 {
+			b = append(b, ' ')
+			b = append(b, kv[i].(string)...)
+			b = append(b, '=')
+			appendValue(&b, fmt.Sprint(kv[i+1]))
+		}
+		return b
+	})
+}
@@ -0,0 +1,69 @@
+package mylog
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogConfig(t *testing.T) {
+	l, buf := newTestLogger(LstdFlags | Lshortfile)
+	l.SetPrefix("app")
+	l.SetLevelFilter(INFO, ERROR)
+	l.SetFlagsForLevel(ERROR, Llongfile)
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.SetMaxLinesPerSecond(100)
+	l.SetIntegrityHashing(true)
+	l.SetTimeZone(time.UTC)
+	l.LogConfig(INFO)
+	got := buf.String()
+	for _, want := range []string{
+		"msg=\"config level=DEBUG filter=INFO,ERROR ",
+		" flags=date,time,shortfile flags.ERROR=longfile format=logfmt prefix=app ",
+		" output=*bytes.Buffer temp_outputs=0 paused=false closed=false ",
+		" max_lines_per_second=100 integrity=chained time_zone=UTC\" hash=",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("config record %q lacks %q", got, want)
+		}
+	}
+
+	buf.Reset()
+	l.SetLevelFilter(DEBUG)
+	l.LogConfig(INFO)
+	if buf.Len() != 0 {
+		t.Errorf("written at a filtered level: %q", buf.String())
+	}
+}
+
+func TestLogConfigFieldSettings(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetAllowedFieldKeys(FieldKeysReject, "user", "id")
+	l.SetSensitiveKeys(MatchContains, "e")
+	l.SetOmitEmptyFields(true)
+	l.SetCallerSiteLimit(5, time.Second)
+	l.SetVerbosity(2)
+	l.SetEnvironment("prod")
+	l.SetSchemaVersion("2")
+	l.SetNumericLevel(true)
+	setSlogDefault(t, slog.DiscardHandler)
+	l.SetSlogMirror(true)
+	l.SetPauseLimit(10)
+	l.Pause()
+	l.Info("held")
+	l.LogConfig(INFO)
+	l.Resume()
+	got := lines(buf)[1]
+	for _, want := range []string{
+		"[INFO]  env=prod config level=DEBUG ",
+		" flags=none format=text prefix=\"\" ",
+		" paused=true closed=false held=1 pause_limit=10 ",
+		" caller_site_limit=5/1s allowed_keys=reject:id,user sensitive_keys=contains:e ",
+		" verbosity=2 env=prod schema=2 numeric_level=true slog_mirror=true",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("config record %q lacks %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package mylog
+
+import "io"
+
+func isConsole(w io.Writer) bool {
+	return false
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package mylog
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+func isConsole(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	var mode uint32
+	return syscall.GetConsoleMode(syscall.Handle(f.Fd()), &mode) == nil
+}
@@ -0,0 +1,44 @@
+//go:build windows
+
+package mylog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsConsole(t *testing.T) {
+	if isConsole(&bytes.Buffer{}) {
+		t.Error("a buffer is a console")
+	}
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if isConsole(f) {
+		t.Error("a file is a console")
+	}
+}
+
+func TestLineEndingAutoFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(f, "", 0, INFO)
+	l.Info("a\nb")
+	l.SetLineEnding(LineEndingCRLF)
+	l.Info("c")
+	l.Close()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), "[INFO]  a\nb\n[INFO]  c\r\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
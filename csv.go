@@ -0,0 +1,64 @@
+package mylog
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+const csvHeaderRow = "time,level,caller,prefix,message,fields\n"
+
+func appendCSVField(buf *[]byte, v string) {
+	if !strings.ContainsAny(v, ",\"\r\n") {
+		*buf = append(*buf, v...)
+		return
+	}
+	*buf = append(*buf, '"')
+	for i := 0; i < len(v); i++ {
+		if v[i] == '"' {
+			*buf = append(*buf, '"')
+		}
+		*buf = append(*buf, v[i])
+	}
+	*buf = append(*buf, '"')
+}
+
+// formatCSV renders the record in the HeaderCSV style as the columns of
+// csvHeaderRow, quoted per RFC 4180. Columns whose flags are off are empty.
+// The fields column holds the key/value fields, such as those of Event and
+// LogFields, as space-separated key=value pairs, leaving them out of the
+// message column.
+func formatCSV(buf *[]byte, prefix string, h *header, msg, fields []byte) {
+	t, flag := h.time, h.flag
+	if flag&(Lepoch|Lepochmillis) != 0 {
+		appendEpoch(buf, t, flag)
+	} else if flag&(Ldate|Ltime|Lmicroseconds) != 0 {
+		t = h.zoned(t)
+		*buf = t.AppendFormat(*buf, timeLayout(flag))
+	}
+	*buf = append(*buf, ',')
+	appendLevel(buf, h)
+	*buf = append(*buf, ',')
+	if flag&(Lshortfile|Llongfile) != 0 {
+		file := h.file
+		if flag&Lshortfile != 0 {
+			file = shortFile(file)
+		}
+		appendCSVField(buf, file+":"+strconv.Itoa(h.line))
+	}
+	*buf = append(*buf, ',')
+	appendCSVField(buf, strings.TrimSpace(prefix))
+	*buf = append(*buf, ',')
+	appendCSVField(buf, string(bytes.TrimRight(msg, "\n")))
+	*buf = append(*buf, ',')
+	appendCSVField(buf, string(bytes.TrimPrefix(fields, []byte{' '})))
+	*buf = append(*buf, '\n')
+}
+
+// SetCSVHeaderRow makes the HeaderCSV style write a column header row
+// before the first record to each output: again after SetOutput or
+// SetWriterFunc, and for the temporary output of WithOutput.
+func (l *Logger) SetCSVHeaderRow(enabled bool) {
+	l.csvHeader.Store(enabled)
+	l.csvHeaderDone.Store(false)
+}
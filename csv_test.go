@@ -0,0 +1,39 @@
+package mylog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	l, buf := newTestLogger(LstdFlags | LUTC)
+	l.SetHeaderStyle(HeaderCSV)
+	l.SetCSVHeaderRow(true)
+	l.SetPrefix("app")
+	l.Info("a, \"quoted\" message")
+	l.Event("login", "user", "bob", "n", 2)
+	want := csvHeaderRow +
+		"2009-01-23T01:23:23Z,INFO,,app,\"a, \"\"quoted\"\" message\",\n" +
+		"2009-01-23T01:23:23Z,INFO,,app,,event=login user=bob n=2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	var other bytes.Buffer
+	l.SetOutput(&other)
+	l.Info("x")
+	if !strings.HasPrefix(other.String(), csvHeaderRow) {
+		t.Errorf("no header row after SetOutput: %q", other.String())
+	}
+
+	var scoped bytes.Buffer
+	l.WithOutput(&scoped, func() { l.Info("y") })
+	l.Info("z")
+	if !strings.HasPrefix(scoped.String(), csvHeaderRow) {
+		t.Errorf("no header row for WithOutput: %q", scoped.String())
+	}
+	if strings.Count(other.String(), csvHeaderRow) != 1 {
+		t.Errorf("header row repeated after WithOutput: %q", other.String())
+	}
+}
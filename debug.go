@@ -0,0 +1,20 @@
+//go:build !nodebug
+
+package mylog
+
+const debugBuild = true
+
+func (l *Logger) Debug(v ...any) {
+	l.output(DEBUG, 0, 2, func(b []byte, e *entry) []byte {
+		return l.appendArgs(b, e, v)
+	})
+}
+
+func (v Verbose) Info(args ...any) {
+	if !v.on {
+		return
+	}
+	v.l.output(DEBUG, 0, 2, func(b []byte, e *entry) []byte {
+		return v.l.appendArgs(b, e, args)
+	})
+}
@@ -0,0 +1,16 @@
+//go:build nodebug
+
+package mylog
+
+const debugBuild = false
+
+// Debug does nothing in builds with the nodebug tag, whatever the level, so
+// that calls inline away. Arguments are still evaluated at the call site
+// unless the compiler can prove them free of side effects. DEBUG records
+// cannot be re-enabled at run time in such builds, and those from other
+// sources, such as Batch.Add, a DEBUG event level or NewSlogHandler, are
+// dropped as well.
+func (l *Logger) Debug(v ...any) {}
+
+// Info does nothing in builds with the nodebug tag, like Debug.
+func (v Verbose) Info(args ...any) {}
@@ -0,0 +1,55 @@
+//go:build nodebug
+
+package mylog
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDebugCompiledOut(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.Debug("stripped")
+	l.SetVerbosity(5)
+	l.V(1).Info("stripped")
+	if buf.Len() != 0 {
+		t.Errorf("nodebug build wrote %q", buf.String())
+	}
+	if l.V(1).Enabled() {
+		t.Error("V(1).Enabled() = true in a nodebug build")
+	}
+	if got := l.Explain(DEBUG); got != "suppressed: Debug is compiled out by the nodebug build tag" {
+		t.Errorf("Explain(DEBUG) = %q", got)
+	}
+	if got := l.Explain(INFO); got != "logged" {
+		t.Errorf("Explain(INFO) = %q", got)
+	}
+}
+
+func TestLogConfigCompiledOut(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.LogConfig(INFO)
+	if !strings.Contains(buf.String(), " debug=compiled_out ") {
+		t.Errorf("LogConfig = %q", buf.String())
+	}
+}
+
+func TestDebugCompiledOutOtherSources(t *testing.T) {
+	l, buf := newTestLogger(0)
+	b := l.Batch()
+	b.Add(DEBUG, "batched")
+	b.Add(INFO, "kept")
+	b.Commit()
+	h := slog.New(NewSlogHandler(l))
+	if h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("slog handler enabled for Debug in a nodebug build")
+	}
+	h.Debug("from slog")
+	l.SetEventLevel(DEBUG)
+	l.Event("e")
+	if got, want := buf.String(), "[INFO]  kept\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+//go:build !nodebug
+
+package mylog
+
+import "testing"
+
+func TestDebug(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.Debug("detail", 1)
+	l.SetLevel(INFO)
+	l.Debug("hidden")
+	if got := buf.String(); got != "[DEBUG] detail 1\n" {
+		t.Errorf("got %q", got)
+	}
+	if got := l.Explain(DEBUG); got != "suppressed: DEBUG is below the minimum level INFO" {
+		t.Errorf("Explain(DEBUG) = %q", got)
+	}
+}
+
+func TestDebugNumericLevel(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.SetNumericLevel(true)
+	l.Debug("d")
+	if got := buf.String(); got != "level=7 msg=d\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestDebugLevelFilter(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetLevel(ERROR)
+	l.SetLevelFilter(DEBUG, ERROR)
+	l.Debug("d")
+	l.Info("i")
+	l.Error("e")
+	if got, want := buf.String(), "[DEBUG] d\n[ERROR] e\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+package mylog
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// SetDefaultFields adds the key/value pairs in args to every record at
+// level, after the record's own fields. A record's own field wins over a
+// default with the same key. Calling it again for the same level replaces
+// the defaults; no args removes them.
+func (l *Logger) SetDefaultFields(level Level, args ...any) {
+	args = slices.Clone(args)
+	for i := 0; i+1 < len(args); i += 2 {
+		args[i] = fmt.Sprint(args[i])
+	}
+	l.defaultFieldsMu.Lock()
+	defer l.defaultFieldsMu.Unlock()
+	m := make(map[Level][]any)
+	if old := l.defaultFields.Load(); old != nil {
+		maps.Copy(m, *old)
+	}
+	if len(args) == 0 {
+		delete(m, level)
+	} else {
+		m[level] = args
+	}
+	if len(m) == 0 {
+		l.defaultFields.Store(nil)
+		return
+	}
+	l.defaultFields.Store(&m)
+}
+
+// withDefaultFields wraps appendOutput to append the defaults in kv whose
+// keys the record's own fields do not use.
+func (l *Logger) withDefaultFields(appendOutput func([]byte, *entry) []byte, kv []any) func([]byte, *entry) []byte {
+	return func(b []byte, e *entry) []byte {
+		b = appendOutput(b, e)
+		newline := len(b) > e.msgStart && b[len(b)-1] == '\n'
+		if newline {
+			b = b[:len(b)-1]
+		}
+		defaults := make([]any, 0, len(kv))
+		for i := 0; i < len(kv); i += 2 {
+			if i+1 < len(kv) && slices.ContainsFunc(e.fields, func(f field) bool { return f.key == kv[i] }) {
+				continue
+			}
+			defaults = append(defaults, kv[i:min(i+2, len(kv))]...)
+		}
+		b = l.appendFields(b, e, defaults)
+		if newline {
+			b = append(b, '\n')
+		}
+		return b
+	}
+}
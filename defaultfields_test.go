@@ -0,0 +1,33 @@
+package mylog
+
+import "testing"
+
+func TestDefaultFields(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetDefaultFields(ERROR, "alert", true)
+	l.Info("ok")
+	l.Error("failed")
+	l.Error("lookup failed:", fieldError{})
+	l.SetEventLevel(ERROR)
+	l.Event("page", "alert", "oncall", "n", 1)
+	l.SetDefaultFields(ERROR)
+	l.Error("cleared")
+	want := "[INFO]  ok\n" +
+		"[ERROR] failed alert=true\n" +
+		"[ERROR] lookup failed: not found id=42 table=users alert=true\n" +
+		"[ERROR] event=page alert=oncall n=1\n" +
+		"[ERROR] cleared\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDefaultFieldsCSV(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetHeaderStyle(HeaderCSV)
+	l.SetDefaultFields(INFO, "region", "eu")
+	l.Info("hello")
+	if got, want := buf.String(), ",INFO,,,hello,region=eu\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+package mylog
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+var flagNames = []struct {
+	name string
+	flag int
+}{
+	{"date", Ldate},
+	{"time", Ltime},
+	{"microseconds", Lmicroseconds},
+	{"longfile", Llongfile},
+	{"shortfile", Lshortfile},
+	{"utc", LUTC},
+	{"recordid", Lrecordid},
+	{"package", Lpackage},
+	{"uptime", Luptime},
+	{"counter", Lcounter},
+	{"thread", Lthread},
+	{"epoch", Lepoch},
+	{"epochmillis", Lepochmillis},
+	{"sortkey", Lsortkey},
+}
+
+func parseFlag(name string) (int, bool) {
+	if name == "std" {
+		return LstdFlags, true
+	}
+	for _, f := range flagNames {
+		if f.name == name {
+			return f.flag, true
+		}
+	}
+	return 0, false
+}
+
+var dsnFormats = map[string]HeaderStyle{
+	"text":   HeaderPositional,
+	"logfmt": HeaderKeyValue,
+	"csv":    HeaderCSV,
+}
+
+var dsnLevels = map[string]Level{
+	"debug": DEBUG,
+	"info":  INFO,
+	"error": ERROR,
+}
+
+var dsnParams = []string{"level", "format", "flags", "prefix"}
+
+// NewFromDSN builds a logger from a URL such as
+//
+//	file:///var/log/app.log?level=info&format=logfmt&flags=date,time
+//
+// The scheme selects the output: file (appending to the path), stdout,
+// stderr, or tcp (tcp://host:port). The query may set level (debug, info,
+// error; default info), format (text, logfmt, csv; default text), flags (a
+// comma-separated list of flag names such as date, time, shortfile, utc;
+// default std) and prefix; any other parameter is an error. Close the
+// logger to release a file or connection.
+func NewFromDSN(dsn string) (*Logger, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("mylog: invalid DSN %q: %w", dsn, err)
+	}
+	q := u.Query()
+	for _, k := range slices.Sorted(maps.Keys(q)) {
+		if !slices.Contains(dsnParams, k) {
+			return nil, fmt.Errorf("mylog: DSN %q: unknown parameter %q", dsn, k)
+		}
+	}
+
+	level := INFO
+	if s := q.Get("level"); s != "" {
+		var ok bool
+		if level, ok = dsnLevels[strings.ToLower(s)]; !ok {
+			return nil, fmt.Errorf("mylog: DSN %q: unknown level %q", dsn, s)
+		}
+	}
+	style := HeaderPositional
+	if s := q.Get("format"); s != "" {
+		var ok bool
+		if style, ok = dsnFormats[strings.ToLower(s)]; !ok {
+			return nil, fmt.Errorf("mylog: DSN %q: unknown format %q", dsn, s)
+		}
+	}
+	flag := LstdFlags
+	if s := q.Get("flags"); s != "" {
+		flag = 0
+		for _, name := range strings.Split(s, ",") {
+			f, ok := parseFlag(strings.ToLower(strings.TrimSpace(name)))
+			if !ok {
+				return nil, fmt.Errorf("mylog: DSN %q: unknown flag %q", dsn, name)
+			}
+			flag |= f
+		}
+	}
+
+	var out io.Writer
+	switch u.Scheme {
+	case "stdout":
+		out = os.Stdout
+	case "stderr":
+		out = os.Stderr
+	case "file":
+		path := u.Path
+		if path == "" {
+			path = u.Opaque
+		}
+		if path == "" {
+			return nil, fmt.Errorf("mylog: DSN %q: missing file path", dsn)
+		}
+		f, err := openLogFile(path)
+		if err != nil {
+			return nil, err
+		}
+		out = f
+	case "tcp":
+		if u.Host == "" {
+			return nil, fmt.Errorf("mylog: DSN %q: missing host:port", dsn)
+		}
+		conn, err := net.Dial("tcp", u.Host)
+		if err != nil {
+			return nil, err
+		}
+		out = conn
+	default:
+		return nil, fmt.Errorf("mylog: DSN %q: unsupported scheme %q", dsn, u.Scheme)
+	}
+
+	l := New(out, q.Get("prefix"), flag, level)
+	l.SetHeaderStyle(style)
+	return l, nil
+}
@@ -0,0 +1,90 @@
+package mylog
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewFromDSNFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	l, err := NewFromDSN("file://" + path + "?level=error&format=logfmt&flags=utc&prefix=svc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Info("hidden")
+	l.Error("failed")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), "level=ERROR prefix=svc msg=failed\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestNewFromDSNDefaults(t *testing.T) {
+	l, err := NewFromDSN("stderr://")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Writer() != os.Stderr || l.Level() != INFO || l.Flags() != LstdFlags || l.Prefix() != "" {
+		t.Errorf("got writer %v, level %v, flags %d, prefix %q", l.Writer(), l.Level(), l.Flags(), l.Prefix())
+	}
+	if HeaderStyle(l.headerStyle.Load()) != HeaderPositional {
+		t.Error("default format is not text")
+	}
+}
+
+func TestNewFromDSNTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	got := make(chan string)
+	go func() {
+		c, err := ln.Accept()
+		if err != nil {
+			got <- err.Error()
+			return
+		}
+		defer c.Close()
+		line, _ := bufio.NewReader(c).ReadString('\n')
+		got <- line
+	}()
+
+	l, err := NewFromDSN("tcp://" + ln.Addr().String() + "?flags=shortfile,utc&level=DEBUG")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	l.Info("over tcp")
+	if line := <-got; !strings.HasPrefix(line, "[INFO]  dsn_test.go:") || !strings.HasSuffix(line, ": over tcp\n") {
+		t.Errorf("received %q", line)
+	}
+}
+
+func TestNewFromDSNErrors(t *testing.T) {
+	for _, dsn := range []string{
+		"stdout://?level=verbose",
+		"stdout://?format=json",
+		"stdout://?flags=date,bogus",
+		"stdout:?levle=debug",
+		"udp://localhost:514",
+		"file://",
+		"tcp://",
+		"://bad",
+	} {
+		if l, err := NewFromDSN(dsn); err == nil {
+			l.Close()
+			t.Errorf("NewFromDSN(%q) succeeded", dsn)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package mylog
+
+import "os"
+
+// DefaultEnvVar is the environment variable read by SetEnvironmentFromEnv
+// when none is given.
+const DefaultEnvVar = "APP_ENV"
+
+// SetEnvironment tags every record with env=<env>, such as production or
+// staging. An empty env removes the tag. HeaderCSV records are not tagged.
+func (l *Logger) SetEnvironment(env string) {
+	if env == "" {
+		l.env.Store(nil)
+		return
+	}
+	l.env.Store(&env)
+}
+
+// SetEnvironmentFromEnv reads the environment variable key, or
+// DefaultEnvVar if key is empty, once and passes its value to
+// SetEnvironment. If the variable is unset or empty, unset is used instead,
+// so an empty unset omits the tag.
+func (l *Logger) SetEnvironmentFromEnv(key, unset string) {
+	if key == "" {
+		key = DefaultEnvVar
+	}
+	env := os.Getenv(key)
+	if env == "" {
+		env = unset
+	}
+	l.SetEnvironment(env)
+}
+
+func (l *Logger) environment() string {
+	if p := l.env.Load(); p != nil {
+		return *p
+	}
+	return ""
+}
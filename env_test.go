@@ -0,0 +1,42 @@
+package mylog
+
+import "testing"
+
+func TestEnvironment(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetEnvironment("staging")
+	l.Info("a")
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.Info("b")
+	l.SetHeaderStyle(HeaderCSV)
+	l.Info("c")
+	l.SetEnvironment("")
+	l.SetHeaderStyle(HeaderPositional)
+	l.Info("d")
+	want := "[INFO]  env=staging a\n" +
+		"level=INFO env=staging msg=b\n" +
+		",INFO,,,c,\n" +
+		"[INFO]  d\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestEnvironmentFromEnv(t *testing.T) {
+	l, _ := newTestLogger(0)
+	t.Setenv(DefaultEnvVar, "production")
+	l.SetEnvironmentFromEnv("", "dev")
+	if got := l.environment(); got != "production" {
+		t.Errorf("from %s: %q", DefaultEnvVar, got)
+	}
+
+	t.Setenv("DEPLOY_ENV", "")
+	l.SetEnvironmentFromEnv("DEPLOY_ENV", "dev")
+	if got := l.environment(); got != "dev" {
+		t.Errorf("unset variable: %q, want dev", got)
+	}
+	l.SetEnvironmentFromEnv("DEPLOY_ENV", "")
+	if got := l.environment(); got != "" {
+		t.Errorf("unset variable with no default: %q", got)
+	}
+}
@@ -0,0 +1,23 @@
+package mylog
+
+// Event logs an analytics event as an event=<name> field followed by props
+// as key=value fields, at the level set by SetEventLevel (INFO by default).
+// The event field is written whatever the allowed and sensitive keys.
+func (l *Logger) Event(name string, props ...any) {
+	l.output(l.EventLevel(), 0, 2, func(b []byte, e *entry) []byte {
+		b = l.appendField(b, e, "event", name, name)
+		return l.appendFields(b, e, props)
+	})
+}
+
+// SetEventLevel sets the level at which Event records are logged.
+func (l *Logger) SetEventLevel(level Level) {
+	l.eventLevel.Store(int32(level) + 1)
+}
+
+func (l *Logger) EventLevel() Level {
+	if v := l.eventLevel.Load(); v > 0 {
+		return Level(v - 1)
+	}
+	return INFO
+}
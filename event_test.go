@@ -0,0 +1,27 @@
+package mylog
+
+import "testing"
+
+func TestEvent(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.Event("signup", "plan", "pro", "seats", 3)
+	l.Event("odd", "dangling")
+	l.SetEventLevel(ERROR)
+	if l.EventLevel() != ERROR {
+		t.Errorf("EventLevel = %v", l.EventLevel())
+	}
+	l.SetLevel(ERROR)
+	l.Event("alert")
+	l.SetEventLevel(INFO)
+	l.Event("hidden")
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.SetLevel(INFO)
+	l.Event("kv", "a", "b c")
+	want := "[INFO]  event=signup plan=pro seats=3\n" +
+		"[INFO]  event=odd !BADKEY=dangling\n" +
+		"[ERROR] event=alert\n" +
+		"level=INFO event=kv a=\"b c\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
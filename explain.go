@@ -0,0 +1,65 @@
+package mylog
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Explain reports, without side effects, whether a record at level would be
+// written right now and, if not, which check would stop it. The checks are
+// those of a real log call, in the same order. Explain does not know the
+// call site of the record, so when SetCallerSiteLimit has call sites at
+// their cap it reports that the record is logged unless it comes from one
+// of them. In builds with the nodebug tag DEBUG is always reported as
+// compiled out.
+func (l *Logger) Explain(level Level) string {
+	name := levelName(level)
+	if level == DEBUG && !debugBuild {
+		return "suppressed: Debug is compiled out by the nodebug build tag"
+	}
+	if f := l.levelFilter.Load(); f != nil {
+		if !f[level] {
+			return fmt.Sprintf("suppressed: %s is not in the level filter", name)
+		}
+	} else if min := l.Level(); level < min {
+		return fmt.Sprintf("suppressed: %s is below the minimum level %s", name, levelName(min))
+	}
+
+	l.outMu.Lock()
+	closed, paused := l.closed, l.paused
+	discard := l.outFunc == nil && l.out == io.Discard && len(l.temp) == 0 && l.routes.Load() == nil
+	l.outMu.Unlock()
+	switch {
+	case closed:
+		return "suppressed: logger is closed"
+	case discard:
+		return "suppressed: output is io.Discard"
+	}
+
+	if lim := l.limiter.Load(); lim != nil && !lim.peek(l.now()) {
+		return "suppressed: line rate cap reached"
+	}
+	if s := l.siteLimit.Load(); s != nil {
+		if n := s.capped(l.now()); n > 0 {
+			return fmt.Sprintf("logged unless its call site is one of the %d at the caller site limit", n)
+		}
+	}
+	if paused {
+		return "held: logger is paused until Resume"
+	}
+	return "logged"
+}
+
+func (r *lineLimiter) peek(now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last.IsZero() {
+		return r.rate >= 1
+	}
+	tokens := r.tokens
+	if elapsed := now.Sub(r.last); elapsed > 0 {
+		tokens = min(r.rate, tokens+elapsed.Seconds()*r.rate)
+	}
+	return tokens >= 1
+}
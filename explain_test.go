@@ -0,0 +1,45 @@
+package mylog
+
+import (
+	"io"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	l, buf := newTestLogger(0)
+	check := func(level Level, want string) {
+		t.Helper()
+		if got := l.Explain(level); got != want {
+			t.Errorf("Explain(%s) = %q, want %q", levelName(level), got, want)
+		}
+	}
+
+	check(INFO, "logged")
+	l.SetLevel(ERROR)
+	check(INFO, "suppressed: INFO is below the minimum level ERROR")
+	l.SetLevelFilter(INFO)
+	check(INFO, "logged")
+	check(ERROR, "suppressed: ERROR is not in the level filter")
+	l.ClearLevelFilter()
+	l.SetLevel(INFO)
+
+	l.SetMaxLinesPerSecond(1)
+	check(INFO, "logged")
+	check(INFO, "logged")
+	l.Info("uses the only token")
+	check(INFO, "suppressed: line rate cap reached")
+	l.SetMaxLinesPerSecond(0)
+
+	l.Pause()
+	check(INFO, "held: logger is paused until Resume")
+	l.Resume()
+
+	l.SetOutput(io.Discard)
+	check(INFO, "suppressed: output is io.Discard")
+	remove := l.AddTempOutput(buf)
+	check(INFO, "logged")
+	remove()
+
+	l.Close()
+	check(INFO, "suppressed: logger is closed")
+}
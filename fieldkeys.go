@@ -0,0 +1,59 @@
+package mylog
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FieldKeyMode selects how fields with keys outside the allowed set are
+// handled.
+type FieldKeyMode uint8
+
+const (
+	FieldKeysAllow FieldKeyMode = iota
+	FieldKeysWarn
+	FieldKeysReject
+)
+
+type allowedKeys struct {
+	mode FieldKeyMode
+	keys map[string]bool
+
+	// warned holds the unknown keys already reported.
+	warned sync.Map
+}
+
+// SetAllowedFieldKeys restricts the keys of key/value fields, such as those
+// from LogFields, Event and InfoChanged, to keys. With FieldKeysWarn an
+// unknown key is still written; with FieldKeysReject the field is dropped.
+// Either way a warning naming the key is written to os.Stderr, once per key.
+// A value without a key is checked as the key "!BADKEY".
+// FieldKeysAllow removes the restriction.
+func (l *Logger) SetAllowedFieldKeys(mode FieldKeyMode, keys ...string) {
+	if mode == FieldKeysAllow {
+		l.allowedKeys.Store(nil)
+		return
+	}
+	a := &allowedKeys{mode: mode, keys: make(map[string]bool, len(keys))}
+	for _, k := range keys {
+		a.keys[k] = true
+	}
+	l.allowedKeys.Store(a)
+}
+
+// keep reports whether the field with key k should be written.
+func (a *allowedKeys) keep(k string) bool {
+	if a == nil || a.keys[k] {
+		return true
+	}
+	reject := a.mode == FieldKeysReject
+	if _, warned := a.warned.LoadOrStore(k, true); !warned {
+		if reject {
+			fmt.Fprintf(os.Stderr, "mylog: dropped field with unknown key %q\n", k)
+		} else {
+			fmt.Fprintf(os.Stderr, "mylog: field with unknown key %q\n", k)
+		}
+	}
+	return !reject
+}
@@ -0,0 +1,64 @@
+package mylog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAllowedFieldKeysWarn(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetAllowedFieldKeys(FieldKeysWarn, "user")
+	stderr := captureStderr(t, func() {
+		l.Event("e", "user", "bob", "extra", 1)
+		l.Event("e", "extra", 2)
+	})
+	want := "[INFO]  event=e user=bob extra=1\n[INFO]  event=e extra=2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := stderr, "mylog: field with unknown key \"extra\"\n"; got != want {
+		t.Errorf("stderr = %q, want one warning %q", got, want)
+	}
+}
+
+func TestAllowedFieldKeysReject(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetAllowedFieldKeys(FieldKeysReject, "user")
+	stderr := captureStderr(t, func() {
+		l.Event("e", "user", "bob", "extra", 1, "dangling")
+		l.Event("e", "extra", 2, "dangling")
+	})
+	want := "[INFO]  event=e user=bob\n[INFO]  event=e\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if n := strings.Count(stderr, "\n"); n != 2 ||
+		!strings.Contains(stderr, `dropped field with unknown key "extra"`) ||
+		!strings.Contains(stderr, `dropped field with unknown key "!BADKEY"`) {
+		t.Errorf("stderr = %q, want one warning per key", stderr)
+	}
+
+	buf.Reset()
+	l.SetAllowedFieldKeys(FieldKeysAllow)
+	l.Event("e", "extra", 3)
+	if got := buf.String(); got != "[INFO]  event=e extra=3\n" {
+		t.Errorf("after FieldKeysAllow: %q", got)
+	}
+}
+
+func TestAllowedFieldKeysInfoChanged(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetAllowedFieldKeys(FieldKeysReject, "user")
+	stderr := captureStderr(t, func() {
+		l.InfoChanged("user", "bob")
+		l.InfoChanged("extra", 1, "changed")
+		l.InfoChanged("extra", 2, "changed")
+	})
+	want := "[INFO]  user=bob\n[INFO]  changed\n[INFO]  changed\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := stderr, "mylog: dropped field with unknown key \"extra\"\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package mylog
+
+import (
+	"fmt"
+	"time"
+)
+
+// LogFielder is implemented by errors that carry structured context. When
+// such an error, or one wrapping it, is logged, its key/value pairs are
+// written as key=value fields after the message.
+type LogFielder interface {
+	LogFields() []any
+}
+
+// SetFieldEncoder installs enc to render key/value field values, such as
+// those from LogFields, Event and InfoChanged. When enc returns false the
+// value is rendered as by default: time.Time values with the layout set by
+// SetTimeFieldLayout, HumanDur values in the SetDurationStyle style and
+// everything else with fmt.Sprint. A nil enc removes it.
+func (l *Logger) SetFieldEncoder(enc func(v any) (string, bool)) {
+	if enc == nil {
+		l.fieldEncoder.Store(nil)
+		return
+	}
+	l.fieldEncoder.Store(&enc)
+}
+
+func (l *Logger) fieldString(v any) string {
+	if c, ok := v.(changedValue); ok {
+		if !c.seen {
+			return l.fieldString(c.new)
+		}
+		return l.fieldString(c.old) + "->" + l.fieldString(c.new)
+	}
+	if enc := l.fieldEncoder.Load(); enc != nil {
+		if s, ok := (*enc)(v); ok {
+			return s
+		}
+	}
+	switch v := v.(type) {
+	case humanDuration:
+		return l.durationString(v)
+	case time.Time:
+		layout := time.RFC3339
+		if p := l.timeLayout.Load(); p != nil {
+			layout = *p
+		}
+		return v.Format(layout)
+	}
+	return fmt.Sprint(v)
+}
+
+// SetTimeFieldLayout sets the layout used to render time.Time field values.
+// The default is time.RFC3339; an empty layout restores it. Values are
+// rendered in their own location.
+func (l *Logger) SetTimeFieldLayout(layout string) {
+	if layout == "" {
+		l.timeLayout.Store(nil)
+		return
+	}
+	l.timeLayout.Store(&layout)
+}
+
+// appendFields appends kv as key=value pairs to b, or to e.fieldText when
+// the style keeps fields apart from the message, applying the allowed keys,
+// sensitive keys and omitempty settings.
+func (l *Logger) appendFields(b []byte, e *entry, kv []any) []byte {
+	kv = spliceHumanPairs(resolveLazy(kv))
+	allowed := l.allowedKeys.Load()
+	sensitive := l.sensitive.Load()
+	omitEmpty := l.omitEmpty.Load()
+	offload := l.offload.Load()
+	for i := 0; i < len(kv); i += 2 {
+		if i+1 == len(kv) {
+			if allowed.keep("!BADKEY") {
+				b = l.appendField(b, e, "!BADKEY", kv[i], l.fieldString(kv[i]))
+			}
+			break
+		}
+		v := kv[i+1]
+		if omitEmpty && isEmptyField(v) {
+			continue
+		}
+		k := fmt.Sprint(kv[i])
+		if !allowed.keep(k) {
+			continue
+		}
+		var text string
+		if sensitive.masks(k) {
+			v, text = maskedValue, maskedValue
+		} else {
+			text = offload.store(l.fieldString(v))
+		}
+		b = l.appendField(b, e, k, v, text)
+	}
+	return b
+}
+
+// appendField appends one key=value field, separated by a space unless it
+// starts the message, without applying the field settings.
+func (l *Logger) appendField(b []byte, e *entry, k string, v any, text string) []byte {
+	out := &b
+	if e.splitFields {
+		out = &e.fieldText
+	}
+	start := len(b)
+	if e.splitFields || len(b) > e.msgStart {
+		*out = append(*out, ' ')
+	}
+	*out = append(*out, k...)
+	*out = append(*out, '=')
+	appendValue(out, text)
+	e.fields = append(e.fields, field{key: k, value: v, text: text})
+	if !e.splitFields {
+		e.spans = append(e.spans, [2]int{start - e.msgStart, len(b) - e.msgStart})
+	}
+	return b
+}
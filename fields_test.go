@@ -0,0 +1,55 @@
+package mylog
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fieldError struct{}
+
+func (fieldError) Error() string    { return "not found" }
+func (fieldError) LogFields() []any { return []any{"id", 42, "table", "users"} }
+
+func TestLogFielder(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.Error("lookup failed:", fmt.Errorf("query: %w", fieldError{}))
+	if got, want := buf.String(), "[ERROR] lookup failed: query: not found id=42 table=users\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+type point struct{ x, y int }
+
+func TestFieldEncoder(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetFieldEncoder(func(v any) (string, bool) {
+		if p, ok := v.(point); ok {
+			return fmt.Sprintf("%d:%d", p.x, p.y), true
+		}
+		return "", false
+	})
+	l.Event("move", "to", point{1, 2}, "n", 3)
+	l.SetFieldEncoder(nil)
+	l.Event("move", "to", point{1, 2})
+	want := "[INFO]  event=move to=1:2 n=3\n[INFO]  event=move to=\"{1 2}\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTimeFieldLayout(t *testing.T) {
+	l, buf := newTestLogger(0)
+	at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.FixedZone("", 3600))
+	l.Event("e", "at", at)
+	l.SetTimeFieldLayout(time.Kitchen)
+	l.Event("e", "at", at)
+	l.SetTimeFieldLayout("")
+	l.Event("e", "at", at)
+	want := "[INFO]  event=e at=2024-05-06T07:08:09+01:00\n" +
+		"[INFO]  event=e at=7:08AM\n" +
+		"[INFO]  event=e at=2024-05-06T07:08:09+01:00\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,38 @@
+package mylog
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Go runs f in a new goroutine. If f panics, the panic value and stack are
+// logged at ERROR, attributed to the Go call, and the panic is then
+// re-raised unless SetSwallowPanics(true) is in effect.
+func (l *Logger) Go(f func()) {
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+	go func() {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			stack := debug.Stack()
+			l.output(ERROR, pcs[0], 0, func(b []byte, e *entry) []byte {
+				b = fmt.Appendf(b, "panic: %v\n", r)
+				return append(b, stack...)
+			})
+			if !l.swallowPanics.Load() {
+				panic(r)
+			}
+		}()
+		f()
+	}()
+}
+
+// SetSwallowPanics controls whether Go recovers from panics after logging
+// them instead of re-panicking, which crashes the program.
+func (l *Logger) SetSwallowPanics(swallow bool) {
+	l.swallowPanics.Store(swallow)
+}
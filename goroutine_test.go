@@ -0,0 +1,39 @@
+package mylog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGoLogsPanic(t *testing.T) {
+	var out syncBuffer
+	l := New(&out, "", Lshortfile, INFO)
+	l.SetSwallowPanics(true)
+	done := make(chan struct{})
+	line := thisLine() + 1
+	l.Go(func() {
+		defer close(done)
+		panic("boom")
+	})
+	<-done
+	waitFor(t, func() bool { return out.String() != "" })
+	got := out.String()
+	if want := fmt.Sprintf("[ERROR] goroutine_test.go:%d: panic: boom\n", line); !strings.HasPrefix(got, want) {
+		t.Errorf("got %q, want prefix %q", got, want)
+	}
+	if !strings.Contains(got, "runtime/debug.Stack") {
+		t.Errorf("no stack in %q", got)
+	}
+}
+
+func TestGoNoPanic(t *testing.T) {
+	var out syncBuffer
+	l := New(&out, "", 0, INFO)
+	done := make(chan struct{})
+	l.Go(func() { close(done) })
+	<-done
+	if got := out.String(); got != "" {
+		t.Errorf("logged %q", got)
+	}
+}
@@ -0,0 +1,63 @@
+package mylog
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"sync"
+)
+
+// GzipWriter compresses records on the fly into a gzip stream. Flushing the
+// stream after each record keeps the output readable by tools such as
+// zcat while it is being written, at the cost of compression ratio;
+// flushing less often compresses better.
+type GzipWriter struct {
+	mu         sync.Mutex
+	w          io.Writer
+	zw         *gzip.Writer
+	flushBytes int
+	pending    int
+}
+
+// NewGzipWriter returns a GzipWriter writing to w. With flushBytes <= 0 the
+// stream is flushed after every Write; otherwise it is flushed once at
+// least flushBytes uncompressed bytes have been written since the last
+// flush.
+func NewGzipWriter(w io.Writer, flushBytes int) *GzipWriter {
+	return &GzipWriter{w: w, zw: gzip.NewWriter(w), flushBytes: flushBytes}
+}
+
+func (g *GzipWriter) Write(p []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	n, err := g.zw.Write(p)
+	if err != nil {
+		return n, err
+	}
+	g.pending += n
+	if g.pending >= g.flushBytes {
+		g.pending = 0
+		err = g.zw.Flush()
+	}
+	return n, err
+}
+
+// Flush writes any buffered compressed data to the underlying writer.
+func (g *GzipWriter) Flush() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pending = 0
+	return g.zw.Flush()
+}
+
+// Close writes the gzip footer and closes the underlying writer if it
+// implements io.Closer.
+func (g *GzipWriter) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	err := g.zw.Close()
+	if c, ok := g.w.(io.Closer); ok {
+		err = errors.Join(err, c.Close())
+	}
+	return err
+}
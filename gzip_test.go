@@ -0,0 +1,76 @@
+package mylog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+)
+
+type closeBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closeBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func gunzip(t *testing.T, b []byte) string {
+	t.Helper()
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A stream that is still being written has no footer, so read what is
+	// there and ignore the unexpected EOF.
+	got, err := io.ReadAll(zr)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		t.Fatal(err)
+	}
+	return string(got)
+}
+
+func TestGzipWriter(t *testing.T) {
+	var dst closeBuffer
+	g := NewGzipWriter(&dst, 0)
+	l := New(g, "", 0, INFO)
+	l.Info("first")
+	if got := gunzip(t, dst.Bytes()); got != "[INFO]  first\n" {
+		t.Errorf("readable mid-stream: %q", got)
+	}
+	l.Info("second")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.closed {
+		t.Error("underlying writer not closed")
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(dst.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("complete stream: %v", err)
+	}
+	if string(got) != "[INFO]  first\n[INFO]  second\n" {
+		t.Errorf("decompressed %q", got)
+	}
+}
+
+func TestGzipWriterFlushBytes(t *testing.T) {
+	var dst bytes.Buffer
+	g := NewGzipWriter(&dst, 1024)
+	g.Write([]byte("short record\n"))
+	if got := gunzip(t, dst.Bytes()); got != "" {
+		t.Errorf("flushed before flushBytes: %q", got)
+	}
+	if err := g.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := gunzip(t, dst.Bytes()); got != "short record\n" {
+		t.Errorf("after Flush: %q", got)
+	}
+}
@@ -0,0 +1,164 @@
+package mylog
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DurationStyle selects how HumanDur fields are rendered.
+type DurationStyle uint8
+
+const (
+	// DurationGo renders durations as time.Duration.String does, e.g. 2m3s.
+	DurationGo DurationStyle = iota
+	// DurationLong renders the largest unit in English, followed by the
+	// next smaller unit when it is nonzero, e.g. 2 minutes 3 seconds,
+	// 1 second 500 milliseconds or 350 milliseconds.
+	DurationLong
+)
+
+type humanDuration time.Duration
+
+func (d humanDuration) String() string {
+	return time.Duration(d).String()
+}
+
+// HumanDur returns the key/value pair key=d for use in LogFields, Event and
+// similar key/value fields, rendered in the style set by SetDurationStyle.
+// The pair may be spread into the fields or passed as one of them.
+// Passed as an argument to Info, Error or Debug, the pair is written as
+// key=d in the same style.
+func HumanDur(key string, d time.Duration) []any {
+	return []any{key, humanDuration(d)}
+}
+
+// SetDurationStyle sets how HumanDur fields are rendered. The default is
+// DurationGo.
+func (l *Logger) SetDurationStyle(style DurationStyle) {
+	l.durationStyle.Store(int32(style))
+}
+
+func (l *Logger) durationString(d humanDuration) string {
+	if DurationStyle(l.durationStyle.Load()) == DurationGo {
+		return time.Duration(d).String()
+	}
+	return longDuration(time.Duration(d))
+}
+
+// styleDurations returns v with HumanDur pairs and values replaced by their
+// text in the logger's duration style, copying v only if it has any.
+func (l *Logger) styleDurations(v []any) []any {
+	cloned := false
+	for i, a := range v {
+		var s string
+		switch a := a.(type) {
+		case humanDuration:
+			s = l.durationString(a)
+		case []any:
+			d, ok := humanPair(a)
+			if !ok {
+				continue
+			}
+			s = fmt.Sprint(a[0]) + "=" + l.durationString(d)
+		default:
+			continue
+		}
+		if !cloned {
+			v = slices.Clone(v)
+			cloned = true
+		}
+		v[i] = s
+	}
+	return v
+}
+
+// spliceHumanPairs returns kv with HumanDur pairs passed whole in a key
+// position, as in Event("job", HumanDur("took", d)), replaced by their key
+// and value, copying kv only if it has any.
+func spliceHumanPairs(kv []any) []any {
+	var out []any
+	spliced := false
+	for i := 0; i < len(kv); {
+		if a, ok := kv[i].([]any); ok {
+			if _, ok := humanPair(a); ok {
+				if !spliced {
+					out = append(out, kv[:i]...)
+					spliced = true
+				}
+				out = append(out, a...)
+				i++
+				continue
+			}
+		}
+		next := min(i+2, len(kv))
+		if spliced {
+			out = append(out, kv[i:next]...)
+		}
+		i = next
+	}
+	if !spliced {
+		return kv
+	}
+	return out
+}
+
+func humanPair(a []any) (humanDuration, bool) {
+	if len(a) != 2 {
+		return 0, false
+	}
+	d, ok := a[1].(humanDuration)
+	return d, ok
+}
+
+var durationUnits = [...]struct {
+	d    time.Duration
+	name string
+}{
+	{time.Hour, "hour"},
+	{time.Minute, "minute"},
+	{time.Second, "second"},
+	{time.Millisecond, "millisecond"},
+	{time.Microsecond, "microsecond"},
+	{time.Nanosecond, "nanosecond"},
+}
+
+func longDuration(d time.Duration) string {
+	var b []byte
+	// The magnitude is taken as a uint64, which -math.MinInt64 fits in.
+	rest := uint64(d)
+	if d < 0 {
+		b = append(b, '-')
+		rest = -rest
+	}
+	if d == 0 {
+		return "0 seconds"
+	}
+	parts := 0
+	for _, u := range durationUnits {
+		if parts == 2 {
+			break
+		}
+		n := rest / uint64(u.d)
+		if n == 0 {
+			// Only the unit right after the largest one is shown.
+			if parts > 0 {
+				break
+			}
+			continue
+		}
+		rest -= n * uint64(u.d)
+		if parts > 0 {
+			b = append(b, ' ')
+		}
+		b = strconv.AppendUint(b, n, 10)
+		b = append(b, ' ')
+		b = append(b, u.name...)
+		if n != 1 {
+			b = append(b, 's')
+		}
+		parts++
+	}
+	return string(b)
+}
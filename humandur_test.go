@@ -0,0 +1,58 @@
+package mylog
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLongDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0 seconds"},
+		{time.Nanosecond, "1 nanosecond"},
+		{350 * time.Millisecond, "350 milliseconds"},
+		{1500 * time.Millisecond, "1 second 500 milliseconds"},
+		{time.Second, "1 second"},
+		{2*time.Minute + 3*time.Second + 400*time.Millisecond, "2 minutes 3 seconds"},
+		{2*time.Hour + 5*time.Second, "2 hours"},
+		{26*time.Hour + time.Minute, "26 hours 1 minute"},
+		{-90 * time.Second, "-1 minute 30 seconds"},
+		{math.MaxInt64, "2562047 hours 47 minutes"},
+		{math.MinInt64, "-2562047 hours 47 minutes"},
+	}
+	for _, tt := range tests {
+		if got := longDuration(tt.d); got != tt.want {
+			t.Errorf("longDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestHumanDur(t *testing.T) {
+	l, buf := newTestLogger(0)
+	d := 1500 * time.Millisecond
+	l.Event("done", HumanDur("took", d)...)
+	l.Info("done", HumanDur("took", d))
+	l.SetDurationStyle(DurationLong)
+	l.Event("done", HumanDur("took", d)...)
+	l.Info("done", HumanDur("took", d))
+	want := "[INFO]  event=done took=1.5s\n" +
+		"[INFO]  done took=1.5s\n" +
+		"[INFO]  event=done took=\"1 second 500 milliseconds\"\n" +
+		"[INFO]  done took=1 second 500 milliseconds\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHumanDurField(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.Event("job", HumanDur("took", 2*time.Minute), "n", 1)
+	l.Event("job", "n", 1, HumanDur("took", time.Second))
+	want := "[INFO]  event=job took=2m0s n=1\n[INFO]  event=job n=1 took=1s\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package mylog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:4])
+}
+
+func TestIntegrityHashing(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetIntegrityHashing(false)
+	l.Info("hello")
+	if got, want := buf.String(), "[INFO]  hello hash="+shortHash("hello")+"\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	l.SetIntegrityHashing(true)
+	l.Info("a")
+	l.Info("b")
+	h1 := shortHash("00000000a")
+	h2 := shortHash(h1 + "b")
+	want := "[INFO]  a hash=" + h1 + " prev_hash=00000000\n" +
+		"[INFO]  b hash=" + h2 + " prev_hash=" + h1 + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("chained: got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	l.DisableIntegrityHashing()
+	l.Info("c")
+	if got := buf.String(); got != "[INFO]  c\n" {
+		t.Errorf("disabled: got %q", got)
+	}
+}
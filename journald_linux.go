@@ -0,0 +1,146 @@
+//go:build linux
+
+package mylog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// journalSocket is a variable so that tests can point it at a fake journal.
+var journalSocket = "/run/systemd/journal/socket"
+
+// JournaldWriter is a LevelWriter that sends records to the systemd journal
+// over its native socket protocol, mapping levels to syslog priorities.
+// The formatted record is sent as MESSAGE. When it is the logger's output,
+// the record's key/value fields, such as those of Event and LogFields, are
+// also sent as journal fields with upper-cased keys, characters other than
+// letters, digits and '_' replaced by '_', and leading underscores, which
+// journald reserves, removed. Keys that would become MESSAGE, PRIORITY or
+// SYSLOG_IDENTIFIER, which the writer sets itself, are prefixed with F_.
+// Records written by Batch.Commit carry no journal fields.
+type JournaldWriter struct {
+	conn       *net.UnixConn
+	addr       *net.UnixAddr
+	identifier string
+}
+
+// NewJournaldWriter connects to the local journal. Records are tagged with
+// SYSLOG_IDENTIFIER=identifier when it is non-empty. It returns an error if
+// journald is not running.
+func NewJournaldWriter(identifier string) (*JournaldWriter, error) {
+	if _, err := os.Stat(journalSocket); err != nil {
+		return nil, err
+	}
+	addr := &net.UnixAddr{Name: journalSocket, Net: "unixgram"}
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	return &JournaldWriter{conn: conn, addr: addr, identifier: identifier}, nil
+}
+
+func (w *JournaldWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(INFO, p)
+}
+
+func (w *JournaldWriter) WriteLevel(level Level, p []byte) (int, error) {
+	return w.writeFields(level, p, nil)
+}
+
+func (w *JournaldWriter) writeFields(level Level, p []byte, fields []field) (int, error) {
+	var b []byte
+	b = append(b, "PRIORITY="...)
+	b = strconv.AppendInt(b, int64(syslogSeverity(level)), 10)
+	b = append(b, '\n')
+	if w.identifier != "" {
+		b = appendJournalField(b, "SYSLOG_IDENTIFIER", []byte(w.identifier))
+	}
+	b = appendJournalField(b, "MESSAGE", bytes.TrimRight(p, "\n"))
+	for _, f := range fields {
+		if key := journalKey(f.key); key != "" {
+			b = appendJournalField(b, key, []byte(f.text))
+		}
+	}
+	if err := w.send(b); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// send writes the entry as one datagram or, if it is too large for one,
+// through a file descriptor as journald allows.
+func (w *JournaldWriter) send(b []byte) error {
+	_, err := w.conn.WriteToUnix(b, w.addr)
+	if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return err
+	}
+	f, err := os.CreateTemp("/dev/shm", "mylog-journal-")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		return err
+	}
+	_, _, err = w.conn.WriteMsgUnix(nil, syscall.UnixRights(int(f.Fd())), w.addr)
+	return err
+}
+
+// journalKey converts a field key to a valid journal field name, or returns
+// "" if nothing valid remains.
+func journalKey(key string) string {
+	switch name := journalName(key); name {
+	case "MESSAGE", "PRIORITY", "SYSLOG_IDENTIFIER":
+		return "F_" + name
+	default:
+		return name
+	}
+}
+
+func journalName(key string) string {
+	b := make([]byte, 0, len(key))
+	for i := 0; i < len(key) && len(b) < 64; i++ {
+		c := key[i]
+		switch {
+		case c >= 'a' && c <= 'z':
+			c -= 'a' - 'A'
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		default:
+			c = '_'
+		}
+		if c == '_' && len(b) == 0 {
+			continue
+		}
+		b = append(b, c)
+	}
+	if len(b) == 0 || b[0] >= '0' && b[0] <= '9' {
+		return ""
+	}
+	return string(b)
+}
+
+// appendJournalField uses the length-prefixed form for values containing
+// newlines, as the native protocol requires.
+func appendJournalField(b []byte, key string, value []byte) []byte {
+	b = append(b, key...)
+	if bytes.IndexByte(value, '\n') < 0 {
+		b = append(b, '=')
+		b = append(b, value...)
+		return append(b, '\n')
+	}
+	b = append(b, '\n')
+	b = binary.LittleEndian.AppendUint64(b, uint64(len(value)))
+	b = append(b, value...)
+	return append(b, '\n')
+}
+
+func (w *JournaldWriter) Close() error {
+	return w.conn.Close()
+}
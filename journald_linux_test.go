@@ -0,0 +1,199 @@
+//go:build linux
+
+package mylog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// fakeJournal points journalSocket at a socket in a temporary directory.
+func fakeJournal(t *testing.T) *net.UnixConn {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	old := journalSocket
+	journalSocket = path
+	t.Cleanup(func() { journalSocket = old })
+	return conn
+}
+
+// readEntry reads one entry, either inline or, for large entries, from the
+// passed file descriptor.
+func readEntry(t *testing.T, conn *net.UnixConn) []byte {
+	t.Helper()
+	b := make([]byte, 1<<20)
+	oob := make([]byte, syscall.CmsgSpace(4))
+	n, oobn, _, _, err := conn.ReadMsgUnix(b, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oobn == 0 {
+		return b[:n]
+	}
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := os.NewFile(uintptr(fds[0]), "entry")
+	defer f.Close()
+	entry, err := io.ReadAll(io.NewSectionReader(f, 0, 1<<30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entry
+}
+
+func parseEntry(t *testing.T, b []byte) map[string]string {
+	t.Helper()
+	fields := make(map[string]string)
+	for len(b) > 0 {
+		nl := bytes.IndexByte(b, '\n')
+		if nl < 0 {
+			t.Fatalf("unterminated field %q", b)
+		}
+		line := b[:nl]
+		if k, v, ok := bytes.Cut(line, []byte("=")); ok {
+			fields[string(k)] = string(v)
+			b = b[nl+1:]
+			continue
+		}
+		b = b[nl+1:]
+		size := binary.LittleEndian.Uint64(b)
+		fields[string(line)] = string(b[8 : 8+size])
+		b = b[8+size+1:]
+	}
+	return fields
+}
+
+func TestAppendJournalField(t *testing.T) {
+	var b []byte
+	b = appendJournalField(b, "MESSAGE", []byte("one line"))
+	b = appendJournalField(b, "STACK", []byte("a\nb"))
+	got := parseEntry(t, b)
+	if got["MESSAGE"] != "one line" || got["STACK"] != "a\nb" || len(got) != 2 {
+		t.Errorf("fields = %q", got)
+	}
+}
+
+func TestJournaldWriter(t *testing.T) {
+	conn := fakeJournal(t)
+	w, err := NewJournaldWriter("myapp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(w, "", 0, DEBUG)
+	defer l.Close()
+
+	l.Event("login", "user", "bob", "_private", 1, "http.status", 200, "9lives", true)
+	got := parseEntry(t, readEntry(t, conn))
+	want := map[string]string{
+		"PRIORITY":          "6",
+		"SYSLOG_IDENTIFIER": "myapp",
+		"MESSAGE":           "[INFO]  event=login user=bob _private=1 http.status=200 9lives=true",
+		"EVENT":             "login",
+		"USER":              "bob",
+		"PRIVATE":           "1",
+		"HTTP_STATUS":       "200",
+	}
+	if len(got) != len(want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+
+	l.Error("multi\nline")
+	got = parseEntry(t, readEntry(t, conn))
+	if got["PRIORITY"] != "3" || got["MESSAGE"] != "[ERROR] multi\nline" {
+		t.Errorf("multi-line entry = %q", got)
+	}
+
+	l.Event("reserved", "message", "hi", "priority", 1, "syslog_identifier", "other")
+	got = parseEntry(t, readEntry(t, conn))
+	if got["MESSAGE"] != "[INFO]  event=reserved message=hi priority=1 syslog_identifier=other" ||
+		got["PRIORITY"] != "6" || got["SYSLOG_IDENTIFIER"] != "myapp" ||
+		got["F_MESSAGE"] != "hi" || got["F_PRIORITY"] != "1" || got["F_SYSLOG_IDENTIFIER"] != "other" {
+		t.Errorf("reserved keys entry = %q", got)
+	}
+}
+
+func TestJournaldWriterLargeEntry(t *testing.T) {
+	conn := fakeJournal(t)
+	w, err := NewJournaldWriter("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	msg := strings.Repeat("x", 1<<20)
+	if _, err := w.WriteLevel(ERROR, []byte(msg+"\n")); err != nil {
+		t.Fatal(err)
+	}
+	got := parseEntry(t, readEntry(t, conn))
+	if got["PRIORITY"] != "3" || got["MESSAGE"] != msg {
+		t.Errorf("large entry: PRIORITY=%q, %d-byte MESSAGE", got["PRIORITY"], len(got["MESSAGE"]))
+	}
+}
+
+func TestJournalKey(t *testing.T) {
+	tests := []struct{ key, want string }{
+		{"user", "USER"},
+		{"User_ID", "USER_ID"},
+		{"http.status-code", "HTTP_STATUS_CODE"},
+		{"__cursor", "CURSOR"},
+		{"message", "F_MESSAGE"},
+		{"Priority", "F_PRIORITY"},
+		{"syslog.identifier", "F_SYSLOG_IDENTIFIER"},
+		{"message_id", "MESSAGE_ID"},
+		{"1st", ""},
+		{"...", ""},
+		{strings.Repeat("k", 70), strings.Repeat("K", 64)},
+	}
+	for _, tt := range tests {
+		if got := journalKey(tt.key); got != tt.want {
+			t.Errorf("journalKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestNewJournaldWriterMissing(t *testing.T) {
+	old := journalSocket
+	journalSocket = filepath.Join(t.TempDir(), "missing.sock")
+	defer func() { journalSocket = old }()
+	if _, err := NewJournaldWriter(""); err == nil {
+		t.Error("NewJournaldWriter succeeded without a journal")
+	}
+}
+
+func TestJournaldWriterSystem(t *testing.T) {
+	if _, err := os.Stat(journalSocket); err != nil {
+		t.Skip("journald is not running")
+	}
+	w, err := NewJournaldWriter("mylog-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	l := New(w, "", 0, INFO)
+	l.Event("journald_test", "run", 1)
+	if l.BytesWritten() == 0 {
+		t.Error("nothing written to the journal")
+	}
+}
@@ -0,0 +1,93 @@
+package mylog
+
+import (
+	"strconv"
+	"strings"
+)
+
+func appendValue(buf *[]byte, v string) {
+	for i := 0; i < len(v); i++ {
+		if c := v[i]; c <= ' ' || c == '=' || c == '"' || c >= 0x7f {
+			*buf = strconv.AppendQuote(*buf, v)
+			return
+		}
+	}
+	if v == "" {
+		*buf = append(*buf, `""`...)
+		return
+	}
+	*buf = append(*buf, v...)
+}
+
+// formatKeyValue renders the record in the HeaderKeyValue style, e.g.
+// ts=2009-01-23T01:23:23Z level=INFO caller=main.go:8 prefix=app msg="hello world" user=bob.
+// The record's key/value fields follow msg as top-level pairs; msg is left
+// out when it is empty and there are fields, as for Event records.
+func formatKeyValue(buf *[]byte, prefix string, h *header, msg, fields []byte) {
+	t, flag, file := h.time, h.flag, h.file
+	if flag&(Lepoch|Lepochmillis) != 0 {
+		*buf = append(*buf, "ts="...)
+		appendEpoch(buf, t, flag)
+		*buf = append(*buf, ' ')
+	} else if flag&(Ldate|Ltime|Lmicroseconds) != 0 {
+		t = h.zoned(t)
+		*buf = append(*buf, "ts="...)
+		*buf = t.AppendFormat(*buf, timeLayout(flag))
+		*buf = append(*buf, ' ')
+	}
+
+	*buf = append(*buf, "level="...)
+	appendLevel(buf, h)
+
+	if h.schema != "" {
+		*buf = append(*buf, " schema="...)
+		appendValue(buf, h.schema)
+	}
+
+	if flag&Lrecordid != 0 {
+		*buf = append(*buf, " id="...)
+		appendRecordID(buf, t)
+	}
+	if flag&Luptime != 0 {
+		*buf = append(*buf, " uptime="...)
+		appendUptime(buf)
+	}
+	if flag&Lcounter != 0 {
+		*buf = append(*buf, " n="...)
+		itoa(buf, int(h.count), -1)
+	}
+	if flag&Lsortkey != 0 {
+		*buf = append(*buf, " sort="...)
+		appendSortKey(buf, h.time, h.count)
+	}
+	if flag&Lthread != 0 {
+		*buf = append(*buf, " tid="...)
+		appendThread(buf, h.tid)
+	}
+	if h.env != "" {
+		*buf = append(*buf, " env="...)
+		appendValue(buf, h.env)
+	}
+	if flag&Lpackage != 0 {
+		*buf = append(*buf, " source="...)
+		appendValue(buf, h.pkg)
+	}
+	if flag&(Lshortfile|Llongfile) != 0 {
+		if flag&Lshortfile != 0 {
+			file = shortFile(file)
+		}
+		*buf = append(*buf, " caller="...)
+		appendValue(buf, file+":"+strconv.Itoa(h.line))
+	}
+	if prefix != "" {
+		*buf = append(*buf, " prefix="...)
+		appendValue(buf, strings.TrimSpace(prefix))
+	}
+
+	if len(msg) > 0 || len(fields) == 0 {
+		*buf = append(*buf, " msg="...)
+		appendValue(buf, string(msg))
+	}
+	*buf = append(*buf, fields...)
+	*buf = append(*buf, '\n')
+}
@@ -0,0 +1,87 @@
+package mylog
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestKeyValueHeader(t *testing.T) {
+	l, buf := newTestLogger(LstdFlags | Lmicroseconds | LUTC)
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.SetPrefix("app ")
+	l.Info("hello world")
+	l.SetFlags(Lshortfile)
+	line := thisLine() + 1
+	l.Error("x=1")
+	want := "ts=2009-01-23T01:23:23.123456Z level=INFO prefix=app msg=\"hello world\"\n" +
+		fmt.Sprintf("level=ERROR caller=keyvalue_test.go:%d prefix=app msg=\"x=1\"\n", line)
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// parseLogfmt splits a logfmt line into its key/value pairs.
+func parseLogfmt(t *testing.T, line string) map[string]string {
+	t.Helper()
+	m := make(map[string]string)
+	for line != "" {
+		k, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			t.Fatalf("no value for %q", line)
+		}
+		var v string
+		if strings.HasPrefix(rest, `"`) {
+			q, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rest = rest[len(q):]
+			v, _ = strconv.Unquote(q)
+		} else {
+			v, rest, _ = strings.Cut(rest, " ")
+			rest = " " + rest
+		}
+		if _, dup := m[k]; dup {
+			t.Errorf("duplicate key %q", k)
+		}
+		m[k] = v
+		line = strings.TrimPrefix(rest, " ")
+	}
+	return m
+}
+
+func TestKeyValueFields(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.Event("login", "user", "bob smith")
+	l.Error("lookup failed:", fieldError{})
+	l.Info("no fields")
+	want := []map[string]string{
+		{"level": "INFO", "event": "login", "user": "bob smith"},
+		{"level": "ERROR", "msg": "lookup failed: not found", "id": "42", "table": "users"},
+		{"level": "INFO", "msg": "no fields"},
+	}
+	got := lines(buf)
+	if len(got) != len(want) {
+		t.Fatalf("got %q", got)
+	}
+	for i, line := range got {
+		if m := parseLogfmt(t, line); !maps.Equal(m, want[i]) {
+			t.Errorf("line %d = %q, want %q", i, m, want[i])
+		}
+	}
+}
+
+func TestKeyValueIntegrity(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.SetIntegrityHashing(false)
+	l.Event("login", "user", "bob")
+	want := "level=INFO event=login user=bob hash=" + shortHash("event=login user=bob") + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
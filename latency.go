@@ -0,0 +1,49 @@
+package mylog
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// LatencyStats holds latency percentiles for single log calls.
+type LatencyStats struct {
+	P50, P90, P99, Max time.Duration
+}
+
+type nopWriter struct{}
+
+func (nopWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+// MeasureLatency runs iterations Info calls on each of goroutines goroutines
+// concurrently and reports the latency distribution of a single call. The
+// logger uses LstdFlags and a writer that drops its input but is not
+// io.Discard, so records are fully formatted and written.
+func MeasureLatency(iterations, goroutines int) LatencyStats {
+	if iterations <= 0 || goroutines <= 0 {
+		return LatencyStats{}
+	}
+	l := New(nopWriter{}, "", LstdFlags, INFO)
+	samples := make([][]time.Duration, goroutines)
+	var wg sync.WaitGroup
+	for g := range samples {
+		samples[g] = make([]time.Duration, iterations)
+		wg.Add(1)
+		go func(d []time.Duration) {
+			defer wg.Done()
+			for i := range d {
+				start := time.Now()
+				l.Info("latency probe", i)
+				d[i] = time.Since(start)
+			}
+		}(samples[g])
+	}
+	wg.Wait()
+
+	all := slices.Concat(samples...)
+	slices.Sort(all)
+	at := func(p float64) time.Duration {
+		return all[int(p*float64(len(all)-1))]
+	}
+	return LatencyStats{P50: at(0.50), P90: at(0.90), P99: at(0.99), Max: all[len(all)-1]}
+}
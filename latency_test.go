@@ -0,0 +1,13 @@
+package mylog
+
+import "testing"
+
+func TestMeasureLatency(t *testing.T) {
+	s := MeasureLatency(200, 4)
+	if s.P50 <= 0 || s.P50 > s.P90 || s.P90 > s.P99 || s.P99 > s.Max {
+		t.Errorf("percentiles out of order: %+v", s)
+	}
+	if got := MeasureLatency(0, 4); got != (LatencyStats{}) {
+		t.Errorf("no iterations: %+v", got)
+	}
+}
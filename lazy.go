@@ -0,0 +1,45 @@
+package mylog
+
+import (
+	"fmt"
+	"slices"
+)
+
+// LazyValue defers computing a logged value until the record is formatted,
+// so the function is not called for records that are filtered out.
+type LazyValue struct {
+	fn func() any
+}
+
+// Lazy wraps fn as a LazyValue. Arguments of type func() any are treated
+// the same way.
+func Lazy(fn func() any) LazyValue {
+	return LazyValue{fn}
+}
+
+func (v LazyValue) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, fmt.FormatString(f, verb), v.fn())
+}
+
+// resolveLazy returns v with LazyValue and func() any elements replaced by
+// their values, cloning v rather than modifying the caller's slice.
+func resolveLazy(v []any) []any {
+	cloned := false
+	for i, a := range v {
+		var fn func() any
+		switch a := a.(type) {
+		case func() any:
+			fn = a
+		case LazyValue:
+			fn = a.fn
+		default:
+			continue
+		}
+		if !cloned {
+			v = slices.Clone(v)
+			cloned = true
+		}
+		v[i] = fn()
+	}
+	return v
+}
@@ -0,0 +1,23 @@
+package mylog
+
+import "testing"
+
+func TestLazy(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetLevel(INFO)
+	calls := 0
+	v := Lazy(func() any { calls++; return "computed" })
+	l.Debug(v)
+	if calls != 0 {
+		t.Fatalf("lazy value computed for a filtered record")
+	}
+	l.Info("value:", v)
+	l.Event("e", "k", v, "f", func() any { return 7 })
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	want := "[INFO]  value: computed\n[INFO]  event=e k=computed f=7\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,38 @@
+package mylog
+
+import "maps"
+
+// SetFlagsForLevel overrides the flags used for records at level. Levels
+// without an override use the flags set by SetFlags.
+func (l *Logger) SetFlagsForLevel(level Level, flag int) {
+	l.updateLevelFlags(func(m map[Level]int) { m[level] = flag })
+}
+
+// ClearFlagsForLevel removes the override set by SetFlagsForLevel.
+func (l *Logger) ClearFlagsForLevel(level Level) {
+	l.updateLevelFlags(func(m map[Level]int) { delete(m, level) })
+}
+
+func (l *Logger) updateLevelFlags(update func(map[Level]int)) {
+	l.levelFlagsMu.Lock()
+	defer l.levelFlagsMu.Unlock()
+	m := make(map[Level]int)
+	if old := l.levelFlags.Load(); old != nil {
+		maps.Copy(m, *old)
+	}
+	update(m)
+	if len(m) == 0 {
+		l.levelFlags.Store(nil)
+		return
+	}
+	l.levelFlags.Store(&m)
+}
+
+func (l *Logger) flagsFor(level Level) int {
+	if m := l.levelFlags.Load(); m != nil {
+		if flag, ok := (*m)[level]; ok {
+			return flag
+		}
+	}
+	return l.Flags()
+}
@@ -0,0 +1,22 @@
+package mylog
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFlagsForLevel(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetFlagsForLevel(ERROR, Lshortfile)
+	l.Info("plain")
+	line := thisLine() + 1
+	l.Error("located")
+	l.ClearFlagsForLevel(ERROR)
+	l.Error("plain again")
+	want := "[INFO]  plain\n" +
+		fmt.Sprintf("[ERROR] levelflags_test.go:%d: located\n", line) +
+		"[ERROR] plain again\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,36 @@
+package mylog
+
+type LineEnding uint8
+
+const (
+	LineEndingAuto LineEnding = iota
+	LineEndingLF
+	LineEndingCRLF
+)
+
+// SetLineEnding controls record line terminators. With LineEndingAuto, the
+// default, newlines are normalized to "\r\n" only when the output set with
+// SetOutput is a Windows console; files and other writers keep "\n".
+func (l *Logger) SetLineEnding(e LineEnding) {
+	l.lineEnding.Store(int32(e))
+}
+
+func (l *Logger) crlf() bool {
+	switch LineEnding(l.lineEnding.Load()) {
+	case LineEndingCRLF:
+		return true
+	case LineEndingLF:
+		return false
+	}
+	return l.isConsole.Load()
+}
+
+func appendCRLF(dst, p []byte) []byte {
+	for i, c := range p {
+		if c == '\n' && (i == 0 || p[i-1] != '\r') {
+			dst = append(dst, '\r')
+		}
+		dst = append(dst, c)
+	}
+	return dst
+}
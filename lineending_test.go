@@ -0,0 +1,20 @@
+package mylog
+
+import "testing"
+
+func TestLineEnding(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.Info("a\nb")
+	l.SetLineEnding(LineEndingCRLF)
+	l.Info("c\nd")
+	l.Info("e\r\nf")
+	l.SetLineEnding(LineEndingLF)
+	l.Info("g")
+	want := "[INFO]  a\nb\n" +
+		"[INFO]  c\r\nd\r\n" +
+		"[INFO]  e\r\nf\r\n" +
+		"[INFO]  g\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
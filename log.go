@@ -9,9 +9,13 @@
 package mylog
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"runtime"
+	"slices"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -32,21 +36,126 @@ const (
 	Llongfile
 	Lshortfile
 	LUTC
+	Lrecordid
+	Lpackage
+	Luptime
+	Lcounter
+	Lthread
+	Lepoch
+	Lepochmillis
+	Lsortkey
 	LstdFlags = Ldate | Ltime
 )
 
+// LevelWriter is implemented by writers that route or annotate records by
+// level. When the output implements it, WriteLevel is used instead of Write.
+type LevelWriter interface {
+	io.Writer
+	WriteLevel(level Level, p []byte) (n int, err error)
+}
+
 type Logger struct {
-	outMu sync.Mutex
-	out   io.Writer
+	outMu   sync.Mutex
+	out     io.Writer
+	outFunc func() io.Writer
+	temp    []*tempOutput
+	closed  bool
+	done    chan struct{}
+	chain   []io.Writer
+
+	paused     bool
+	pending    []heldRecord
+	pauseLimit int
 
-	prefix    atomic.Pointer[string]
+	prefix    atomic.Pointer[prefixHeader]
 	flag      atomic.Int32
 	isDiscard atomic.Bool
 	minLevel  atomic.Int32
+
+	levelFilter atomic.Pointer[[256]bool]
+	written     atomic.Int64
+
+	lineEnding atomic.Int32
+	isConsole  atomic.Bool
+
+	burst   atomic.Pointer[burstAlert]
+	limiter atomic.Pointer[lineLimiter]
+	dropped atomic.Uint64
+
+	maxBufReuse atomic.Int64
+	headerStyle atomic.Int32
+	counter     atomic.Uint64
+
+	csvHeader     atomic.Bool
+	csvHeaderDone atomic.Bool
+
+	elidePrefix atomic.Bool
+	lastPrefix  atomic.Pointer[prefixHeader]
+
+	swallowPanics atomic.Bool
+
+	levelFlagsMu sync.Mutex
+	levelFlags   atomic.Pointer[map[Level]int]
+
+	integrity atomic.Int32
+	hashMu    sync.Mutex
+	prevHash  string
+
+	created     time.Time
+	counts      [ERROR + 2]atomic.Uint64
+	summary     atomic.Bool
+	summaryDone atomic.Bool
+
+	coarse atomic.Pointer[coarseClock]
+	clock  atomic.Pointer[func() time.Time]
+
+	changedMu sync.Mutex
+	changed   map[string]any
+
+	actionsMu sync.Mutex
+	actions   atomic.Pointer[[]*levelAction]
+
+	eventLevel atomic.Int32
+
+	slowWrite atomic.Pointer[slowWriteAlert]
+
+	loc atomic.Pointer[time.Location]
+
+	copyRecords atomic.Bool
+
+	siteLimit atomic.Pointer[siteLimit]
+
+	fieldEncoder atomic.Pointer[func(any) (string, bool)]
+	allowedKeys  atomic.Pointer[allowedKeys]
+	sensitive    atomic.Pointer[sensitiveKeys]
+	timeLayout   atomic.Pointer[string]
+
+	durationStyle atomic.Int32
+
+	verbosity atomic.Int32
+
+	env    atomic.Pointer[string]
+	schema atomic.Pointer[string]
+
+	numericLevel atomic.Bool
+
+	callerFilter atomic.Pointer[func(runtime.Frame) bool]
+
+	omitEmpty atomic.Bool
+
+	routes atomic.Pointer[[]fieldRoute]
+
+	slogMirror atomic.Bool
+
+	defaultFieldsMu sync.Mutex
+	defaultFields   atomic.Pointer[map[Level][]any]
+
+	offload atomic.Pointer[fieldOffload]
 }
 
 func New(out io.Writer, prefix string, flag int, level Level) *Logger {
 	l := new(Logger)
+	l.created = time.Now()
 	l.SetOutput(out)
 	l.SetPrefix(prefix)
 	l.SetFlags(flag)
@@ -58,31 +167,300 @@ func (l *Logger) SetOutput(w io.Writer) {
 	l.outMu.Lock()
 	defer l.outMu.Unlock()
 	l.out = w
-	l.isDiscard.Store(w == io.Discard)
+	l.outFunc = nil
+	l.updateDiscard()
+	l.isConsole.Store(isConsole(w))
+	l.written.Store(0)
+	l.csvHeaderDone.Store(false)
 }
 
+// WithOutput sends this logger's records to w while f runs and restores
+// the previous output afterwards, even if f panics. The switch affects
+// every goroutine using the logger, not just f; to capture only f's records
+// use a separate logger, or AddTempOutput to keep the regular output too.
+func (l *Logger) WithOutput(w io.Writer, f func()) {
+	l.outMu.Lock()
+	out, outFunc := l.out, l.outFunc
+	l.out, l.outFunc = w, nil
+	l.updateDiscard()
+	l.isConsole.Store(isConsole(w))
+	csvDone := l.csvHeaderDone.Swap(false)
+	l.outMu.Unlock()
+
+	defer func() {
+		l.outMu.Lock()
+		defer l.outMu.Unlock()
+		l.out, l.outFunc = out, outFunc
+		l.updateDiscard()
+		l.isConsole.Store(outFunc == nil && isConsole(out))
+		l.csvHeaderDone.Store(csvDone)
+	}()
+	f()
+}
+
+// SetWriterFunc makes the logger resolve its destination by calling f for
+// every record, under the output lock, instead of using the writer given to
+// SetOutput. f runs on each write, so it should be cheap; a nil result
+// discards the record. Passing nil reverts to the static writer, and a later
+// SetOutput also replaces f.
+func (l *Logger) SetWriterFunc(f func() io.Writer) {
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	l.outFunc = f
+	l.updateDiscard()
+	l.isConsole.Store(f == nil && isConsole(l.out))
+	l.written.Store(0)
+	l.csvHeaderDone.Store(false)
+}
+
+// updateDiscard must be called with outMu held.
+func (l *Logger) updateDiscard() {
+	l.isDiscard.Store(l.closed || l.outFunc == nil && l.out == io.Discard && len(l.temp) == 0 && l.routes.Load() == nil)
+}
+
+func (l *Logger) writer() io.Writer {
+	if l.outFunc != nil {
+		return l.outFunc()
+	}
+	return l.out
+}
+
+// itoa appends i zero-padded to wid digits. Negative values get a leading
+// '-'; wid is capped at 20, the most digits a 64-bit value can have.
 func itoa(buf *[]byte, i int, wid int) {
+	u := uint64(i)
+	if i < 0 {
+		*buf = append(*buf, '-')
+		u = -u
+	}
 	var b [20]byte
+	if wid > len(b) {
+		wid = len(b)
+	}
 	bp := len(b) - 1
-	for i >= 10 || wid > 1 {
+	for u >= 10 || wid > 1 {
 		wid--
-		q := i / 10
-		b[bp] = byte('0' + i - q*10)
+		q := u / 10
+		b[bp] = byte('0' + u - q*10)
 		bp--
-		i = q
+		u = q
 	}
-	b[bp] = byte('0' + i)
+	b[bp] = byte('0' + u)
 	*buf = append(*buf, b[bp:]...)
 }
 
-func formatHeader(buf *[]byte, t time.Time, prefix string, flag int, levelStr string, file string, line int) {
-	*buf = append(*buf, prefix...)
-	*buf = append(*buf, levelStr...)
+// packageName extracts the import path from a qualified function name such
+// as "example.com/a/b.(*T).Method".
+func packageName(function string) string {
+	slash := 0
+	for i := len(function) - 1; i >= 0; i-- {
+		if function[i] == '/' {
+			slash = i
+			break
+		}
+	}
+	for i := slash; i < len(function); i++ {
+		if function[i] == '.' {
+			return function[:i]
+		}
+	}
+	return function
+}
 
-	if flag&(Ldate|Ltime|Lmicroseconds) != 0 {
-		if flag&LUTC != 0 {
-			t = t.UTC()
+func shortFile(file string) string {
+	for i := len(file) - 1; i > 0; i-- {
+		if file[i] == '/' {
+			return file[i+1:]
 		}
+	}
+	return file
+}
+
+func levelName(level Level) string {
+	switch level {
+	case DEBUG:
+		return "DEBUG"
+	case INFO:
+		return "INFO"
+	case ERROR:
+		return "ERROR"
+	default:
+		return "?????"
+	}
+}
+
+// syslogSeverity maps level to a syslog severity: 3 (err) for ERROR and
+// above, 6 (info) for INFO and 7 (debug) otherwise.
+func syslogSeverity(level Level) int {
+	switch {
+	case level >= ERROR:
+		return 3
+	case level == INFO:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// appendLevel appends the level name, or its syslog severity if the
+// logger has SetNumericLevel set.
+func appendLevel(buf *[]byte, h *header) {
+	if h.numeric {
+		itoa(buf, syslogSeverity(h.level), -1)
+		return
+	}
+	*buf = append(*buf, levelName(h.level)...)
+}
+
+func levelLabel(level Level) string {
+	switch level {
+	case DEBUG:
+		return "[DEBUG] "
+	case INFO:
+		return "[INFO]  "
+	case ERROR:
+		return "[ERROR] "
+	default:
+		return "[?????] "
+	}
+}
+
+// prefixHeader holds the prefix together with the precomputed
+// prefix+label bytes for each built-in level and for unknown levels.
+type prefixHeader struct {
+	prefix  string
+	levels  [ERROR + 1][]byte
+	unknown []byte
+	elided  [ERROR + 1][]byte
+	elidedU []byte
+}
+
+func newPrefixHeader(prefix string) *prefixHeader {
+	h := &prefixHeader{prefix: prefix}
+	for level := range h.levels {
+		h.levels[level] = appendPrefixLabel(nil, prefix, Level(level), false)
+		h.elided[level] = appendPrefixLabel(nil, prefix, Level(level), true)
+	}
+	h.unknown = appendPrefixLabel(nil, prefix, ERROR+1, false)
+	h.elidedU = appendPrefixLabel(nil, prefix, ERROR+1, true)
+	return h
+}
+
+func appendPrefixLabel(b []byte, prefix string, level Level, elide bool) []byte {
+	if prefix != "" {
+		if elide {
+			for range len(prefix) {
+				b = append(b, ' ')
+			}
+		} else {
+			b = append(b, prefix...)
+		}
+		if prefix[len(prefix)-1] != ' ' {
+			b = append(b, ' ')
+		}
+	}
+	return append(b, levelLabel(level)...)
+}
+
+func (h *prefixHeader) header(level Level, elide bool) []byte {
+	if int(level) < len(h.levels) {
+		if elide {
+			return h.elided[level]
+		}
+		return h.levels[level]
+	}
+	if elide {
+		return h.elidedU
+	}
+	return h.unknown
+}
+
+var emptyPrefixHeader = newPrefixHeader("")
+
+// appendEpoch appends t as Unix seconds, or milliseconds with Lepochmillis.
+// The epoch flags take precedence over Ldate, Ltime and Lmicroseconds.
+func appendEpoch(buf *[]byte, t time.Time, flag int) {
+	if flag&Lepochmillis != 0 {
+		*buf = strconv.AppendInt(*buf, t.UnixMilli(), 10)
+		return
+	}
+	*buf = strconv.AppendInt(*buf, t.Unix(), 10)
+}
+
+var processStart = time.Now()
+
+// appendUptime appends the time since process start. It is measured on the
+// monotonic clock rather than from the record time, which SetClock and
+// SetCoarseClock may replace.
+func appendUptime(buf *[]byte) {
+	*buf = append(*buf, time.Since(processStart).Round(time.Microsecond).String()...)
+}
+
+// appendSortKey appends the Lsortkey ordering key: the UTC time with
+// microseconds followed by the record counter zero-padded to 20 digits, the
+// width of any uint64, e.g. 2024-01-02T03:04:05.123456Z-00000000000000000042.
+// Keys sort by time and then by counter, so records within the same
+// microsecond keep a total order.
+func appendSortKey(buf *[]byte, t time.Time, count uint64) {
+	*buf = t.UTC().AppendFormat(*buf, "2006-01-02T15:04:05.000000Z")
+	*buf = append(*buf, '-')
+	var b [20]byte
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = byte('0' + count%10)
+		count /= 10
+	}
+	*buf = append(*buf, b[:]...)
+}
+
+// header carries the per-record values rendered by formatHeader and
+// formatKeyValue.
+type header struct {
+	time    time.Time
+	level   Level
+	flag    int
+	pkg     string
+	file    string
+	line    int
+	count   uint64
+	tid     int
+	env     string
+	schema  string
+	numeric bool
+	loc     *time.Location
+}
+
+// zoned converts t to the logger's time zone: the SetTimeZone location if
+// set, which overrides LUTC, then UTC with LUTC, otherwise local time.
+func (h *header) zoned(t time.Time) time.Time {
+	if h.loc != nil {
+		return t.In(h.loc)
+	}
+	if h.flag&LUTC != 0 {
+		return t.UTC()
+	}
+	return t
+}
+
+// appendThread appends the OS thread ID, or "unknown" where it cannot be
+// determined. The ID is only meaningful for goroutines locked to their
+// thread with runtime.LockOSThread; others may move between threads.
+func appendThread(buf *[]byte, tid int) {
+	if tid < 0 {
+		*buf = append(*buf, "unknown"...)
+		return
+	}
+	itoa(buf, tid, -1)
+}
+
+func formatHeader(buf *[]byte, head []byte, h *header) {
+	*buf = append(*buf, head...)
+	t, flag, file := h.time, h.flag, h.file
+
+	if flag&(Lepoch|Lepochmillis) != 0 {
+		appendEpoch(buf, t, flag)
+		*buf = append(*buf, ' ')
+	} else if flag&(Ldate|Ltime|Lmicroseconds) != 0 {
+		t = h.zoned(t)
 		if flag&Ldate != 0 {
 			year, month, day := t.Date()
 			itoa(buf, year, 4)
@@ -107,135 +485,555 @@ func formatHeader(buf *[]byte, t time.Time, prefix string, flag int, levelStr st
 		}
 	}
 
+	if flag&Luptime != 0 {
+		*buf = append(*buf, '+')
+		appendUptime(buf)
+		*buf = append(*buf, ' ')
+	}
+
+	if flag&Lcounter != 0 {
+		*buf = append(*buf, '#')
+		itoa(buf, int(h.count), -1)
+		*buf = append(*buf, ' ')
+	}
+
+	if flag&Lsortkey != 0 {
+		appendSortKey(buf, h.time, h.count)
+		*buf = append(*buf, ' ')
+	}
+
+	if flag&Lthread != 0 {
+		*buf = append(*buf, "tid:"...)
+		appendThread(buf, h.tid)
+		*buf = append(*buf, ' ')
+	}
+
+	if h.env != "" {
+		*buf = append(*buf, "env="...)
+		appendValue(buf, h.env)
+		*buf = append(*buf, ' ')
+	}
+
+	if flag&Lrecordid != 0 {
+		appendRecordID(buf, t)
+		*buf = append(*buf, ' ')
+	}
+
+	if flag&Lpackage != 0 {
+		*buf = append(*buf, h.pkg...)
+		if flag&(Lshortfile|Llongfile) != 0 {
+			*buf = append(*buf, ' ')
+		} else {
+			*buf = append(*buf, ": "...)
+		}
+	}
+
 	if flag&(Lshortfile|Llongfile) != 0 {
 		if flag&Lshortfile != 0 {
-			short := file
-			for i := len(file) - 1; i > 0; i-- {
-				if file[i] == '/' {
-					short = file[i+1:]
-					break
-				}
-			}
-			file = short
+			file = shortFile(file)
 		}
 		*buf = append(*buf, file...)
 		*buf = append(*buf, ':')
-		itoa(buf, line, -1)
+		itoa(buf, h.line, -1)
 		*buf = append(*buf, ": "...)
 	}
 }
 
+type HeaderStyle uint8
+
+const (
+	HeaderPositional HeaderStyle = iota
+	HeaderKeyValue
+	HeaderCSV
+)
+
+func timeLayout(flag int) string {
+	var layout string
+	if flag&Ldate != 0 {
+		layout = "2006-01-02"
+	}
+	if flag&(Ltime|Lmicroseconds) != 0 {
+		if layout != "" {
+			layout += "T"
+		}
+		layout += "15:04:05"
+		if flag&Lmicroseconds != 0 {
+			layout += ".000000"
+		}
+		if flag&Ldate != 0 {
+			layout += "Z07:00"
+		}
+	}
+	return layout
+}
+
 var bufferPool = sync.Pool{New: func() any { return new([]byte) }}
 
+// entry is the record being formatted, as seen by the function appending
+// its message.
+type entry struct {
+	time time.Time
+
+	// fields are the key/value fields written by appendFields, for field
+	// routing, journal fields and slog attributes.
+	fields []field
+
+	// spans are the [start, end) offsets of the fields within the
+	// message, which starts at msgStart in the buffer being appended to.
+	spans    [][2]int
+	msgStart int
+
+	// splitFields makes appendFields write to fieldText instead of the
+	// message, for the HeaderKeyValue and HeaderCSV styles.
+	splitFields bool
+	fieldText   []byte
+
+	// noMirror keeps the record from the slog mirror.
+	noMirror bool
+}
+
+var entryPool = sync.Pool{New: func() any { return new(entry) }}
+
+func getEntry(t time.Time) *entry {
+	e := entryPool.Get().(*entry)
+	*e = entry{time: t, fields: e.fields[:0], spans: e.spans[:0], fieldText: e.fieldText[:0]}
+	return e
+}
+
+func putEntry(e *entry) {
+	if cap(e.fieldText) > defaultMaxBufferReuse {
+		e.fieldText = nil
+	}
+	clear(e.fields)
+	entryPool.Put(e)
+}
+
 func getBuffer() *[]byte {
 	p := bufferPool.Get().(*[]byte)
 	*p = (*p)[:0]
 	return p
 }
 
-func putBuffer(p *[]byte) {
-	if cap(*p) > 64<<10 {
+const defaultMaxBufferReuse = 64 << 10
+
+func putBuffer(p *[]byte, max int) {
+	if cap(*p) > max {
 		*p = nil
 	}
 	bufferPool.Put(p)
 }
 
-func (l *Logger) output(level Level, pc uintptr, calldepth int, appendOutput func([]byte) []byte) error {
-	if int32(level) < l.minLevel.Load() {
+func (l *Logger) enabled(level Level) bool {
+	if level == DEBUG && !debugBuild {
+		return false
+	}
+	if f := l.levelFilter.Load(); f != nil {
+		return f[level]
+	}
+	return int32(level) >= l.minLevel.Load()
+}
+
+func (l *Logger) output(level Level, pc uintptr, calldepth int, appendOutput func([]byte, *entry) []byte) error {
+	now, ok := l.admit(level)
+	if !ok {
 		return nil
 	}
+	if l.siteLimit.Load() != nil {
+		site := pc
+		if site == 0 {
+			var pcs [1]uintptr
+			runtime.Callers(calldepth+1, pcs[:])
+			site = pcs[0]
+		}
+		if !l.checkSite(level, now, site) {
+			return nil
+		}
+	}
+	return l.emit(level, now, pc, calldepth+1, true, appendOutput)
+}
+
+// admit runs the filters a record must pass before it is formatted and
+// returns the record's timestamp.
+func (l *Logger) admit(level Level) (time.Time, bool) {
+	if !l.enabled(level) {
+		return time.Time{}, false
+	}
 
 	if l.isDiscard.Load() {
-		return nil
+		return time.Time{}, false
 	}
 
-	now := time.Now()
+	now := l.now()
 
-	prefix := l.Prefix()
-	flag := l.Flags()
+	if level >= ERROR {
+		if b := l.burst.Load(); b != nil {
+			b.record(now)
+		}
+	}
 
-	var file string
-	var line int
-	if flag&(Lshortfile|Llongfile) != 0 {
-		if pc == 0 {
-			var ok bool
-			_, file, line, ok = runtime.Caller(calldepth)
-			if !ok {
-				file = "???"
-				line = 0
+	if lim := l.limiter.Load(); lim != nil && !lim.allow(now) {
+		l.dropped.Add(1)
+		return time.Time{}, false
+	}
+
+	return now, true
+}
+
+// emit formats and sends a record that has passed admit. Counted records
+// are included in the close summary once written.
+func (l *Logger) emit(level Level, now time.Time, pc uintptr, calldepth int, counted bool, appendOutput func([]byte, *entry) []byte) error {
+	buf := getBuffer()
+	defer putBuffer(buf, l.maxBufferReuse())
+	scratch := getBuffer()
+	defer putBuffer(scratch, l.maxBufferReuse())
+	e := getEntry(now)
+	defer putEntry(e)
+	msg, hashable := l.format(buf, scratch, e, level, pc, calldepth+1, appendOutput)
+
+	if mode := l.integrity.Load(); mode != integrityOff && hashable {
+		if mode == integrityChained {
+			l.hashMu.Lock()
+			defer l.hashMu.Unlock()
+		}
+		l.appendIntegrity(buf, msg, mode == integrityChained)
+		*buf = append(*buf, '\n')
+	}
+
+	r := heldRecord{level: level, p: *buf, fields: e.fields, routes: l.matchRoutes(e.fields)}
+	if counted {
+		r.counts[min(level, ERROR+1)] = 1
+	}
+	err := l.send(r)
+	l.notifyLevel(level, *buf)
+	if l.slogMirror.Load() && !e.noMirror {
+		l.mirrorToSlog(level, now, mirrorMessage(msg, e.spans), e.fields)
+	}
+	return err
+}
+
+// format appends the complete record, ending in a newline, to buf. It
+// returns the message without its trailing newline, which may alias buf or
+// scratch, and whether the style allows integrity hashing.
+func (l *Logger) format(buf, scratch *[]byte, e *entry, level Level, pc uintptr, calldepth int, appendOutput func([]byte, *entry) []byte) (msg []byte, hashable bool) {
+	ph := l.prefixHeader()
+	elide := false
+	if l.elidePrefix.Load() {
+		elide = l.lastPrefix.Swap(ph) == ph
+	}
+	head := ph.header(level, elide)
+	flag := l.flagsFor(level)
+	h := header{time: e.time, level: level, flag: flag, loc: l.loc.Load(), env: l.environment()}
+	if p := l.schema.Load(); p != nil {
+		h.schema = *p
+	}
+	h.numeric = l.numericLevel.Load()
+
+	if flag&(Lshortfile|Llongfile|Lpackage) != 0 {
+		// Frames rather than bare program counters are used so that
+		// inlined callers report their own file and package.
+		var f runtime.Frame
+		switch {
+		case pc != 0:
+			f, _ = runtime.CallersFrames([]uintptr{pc}).Next()
+		case l.callerFilter.Load() != nil:
+			f = l.filteredCaller(calldepth)
+		default:
+			var pcs [1]uintptr
+			if runtime.Callers(calldepth+1, pcs[:]) > 0 {
+				f, _ = runtime.CallersFrames(pcs[:]).Next()
 			}
-		} else {
-			fs := runtime.CallersFrames([]uintptr{pc})
-			f, _ := fs.Next()
-			file = f.File
-			if file == "" {
-				file = "???"
+		}
+		h.file, h.line = f.File, f.Line
+		if h.file == "" {
+			h.file, h.line = "???", 0
+		}
+		if flag&Lpackage != 0 {
+			h.pkg = "???"
+			if f.Function != "" {
+				h.pkg = packageName(f.Function)
 			}
-			line = f.Line
 		}
 	}
 
-	var levelStr string
-	switch level {
-	case DEBUG:
-		levelStr = "[DEBUG] "
-	case INFO:
-		levelStr = "[INFO]  "
-	case ERROR:
-		levelStr = "[ERROR] "
+	if flag&(Lcounter|Lsortkey) != 0 {
+		h.count = l.counter.Add(1)
+	}
+	if flag&Lthread != 0 {
+		h.tid = threadID()
+	}
+
+	if m := l.defaultFields.Load(); m != nil {
+		if kv := (*m)[level]; kv != nil {
+			appendOutput = l.withDefaultFields(appendOutput, kv)
+		}
+	}
+
+	hashable = true
+	switch HeaderStyle(l.headerStyle.Load()) {
+	case HeaderKeyValue:
+		e.splitFields = true
+		*scratch = bytes.TrimRight(appendOutput(*scratch, e), "\n")
+		formatKeyValue(buf, l.Prefix(), &h, *scratch, e.fieldText)
+		// The hash covers the fields after the message; the slog mirror,
+		// which gets them as attributes, skips them.
+		fields := e.fieldText
+		if len(*scratch) == 0 {
+			fields = bytes.TrimPrefix(fields, []byte{' '})
+		}
+		e.spans = append(e.spans, [2]int{len(*scratch), len(*scratch) + len(fields)})
+		*scratch = append(*scratch, fields...)
+		msg = *scratch
+	case HeaderCSV:
+		e.splitFields = true
+		*scratch = appendOutput(*scratch, e)
+		msg = *scratch
+		if l.csvHeader.Load() && !l.csvHeaderDone.Swap(true) {
+			*buf = append(*buf, csvHeaderRow...)
+		}
+		formatCSV(buf, l.Prefix(), &h, msg, e.fieldText)
+		hashable = false
 	default:
-		levelStr = "[?????] "
+		formatHeader(buf, head, &h)
+		start := len(*buf)
+		e.msgStart = start
+		*buf = appendOutput(*buf, e)
+		msg = (*buf)[start:]
 	}
 
-	buf := getBuffer()
-	defer putBuffer(buf)
-	formatHeader(buf, now, prefix, flag, levelStr, file, line)
-	*buf = appendOutput(*buf)
 	if len(*buf) == 0 || (*buf)[len(*buf)-1] != '\n' {
 		*buf = append(*buf, '\n')
 	}
+	return bytes.TrimRight(msg, "\n"), hashable
+}
+
+// heldRecord is a record on its way to the output, and to the writers of
+// the field routes it matched. With routeOnly set the output is skipped.
+type heldRecord struct {
+	level     Level
+	p         []byte
+	fields    []field
+	routes    []io.Writer
+	routeOnly bool
+
+	// counts is the number of records in p at each level, as indexed in
+	// Logger.counts, that the close summary reports once p is written.
+	counts [ERROR + 2]uint32
+}
+
+// fieldWriter is implemented by writers that store a record's key/value
+// fields apart from its text, such as JournaldWriter.
+type fieldWriter interface {
+	writeFields(level Level, p []byte, fields []field) (int, error)
+}
+
+// SetCopyRecords makes the logger hand each writer its own newly allocated
+// copy of the record instead of a pooled buffer that is reused once Write
+// returns. Use it for writers that keep the slice after Write, which
+// io.Writer forbids but some asynchronous writers do; it costs one
+// allocation per record. OnLevelReached actions always get a copy.
+func (l *Logger) SetCopyRecords(enabled bool) {
+	l.copyRecords.Store(enabled)
+}
+
+func (l *Logger) write(level Level, p []byte) error {
+	return l.send(heldRecord{level: level, p: p})
+}
+
+func (l *Logger) send(r heldRecord) error {
+	if l.crlf() {
+		buf := getBuffer()
+		defer putBuffer(buf, l.maxBufferReuse())
+		*buf = appendCRLF(*buf, r.p)
+		r.p = *buf
+	}
+	if l.copyRecords.Load() {
+		r.p = slices.Clone(r.p)
+	}
 
 	l.outMu.Lock()
 	defer l.outMu.Unlock()
-	_, err := l.out.Write(*buf)
+	if l.closed {
+		return nil
+	}
+	if l.paused {
+		l.hold(r)
+		return nil
+	}
+	return l.writeLocked(r)
+}
+
+// writeLocked must be called with outMu held.
+func (l *Logger) writeLocked(r heldRecord) error {
+	level, p := r.level, r.p
+	for _, w := range r.routes {
+		w.Write(p)
+	}
+	if r.routeOnly {
+		return nil
+	}
+	var err error
+	if w := l.writer(); w != nil {
+		slow := l.slowWrite.Load()
+		var start time.Time
+		if slow != nil {
+			start = time.Now()
+		}
+		var n int
+		if fw, ok := w.(fieldWriter); ok {
+			n, err = fw.writeFields(level, p, r.fields)
+		} else if lw, ok := w.(LevelWriter); ok {
+			n, err = lw.WriteLevel(level, p)
+		} else {
+			n, err = w.Write(p)
+		}
+		if slow != nil {
+			if elapsed := time.Since(start); elapsed >= slow.threshold && slow.running.CompareAndSwap(false, true) {
+				go func() {
+					defer slow.running.Store(false)
+					slow.callback(elapsed)
+				}()
+			}
+		}
+		if err == nil {
+			l.written.Add(int64(n))
+		}
+	}
+	for _, t := range l.temp {
+		t.w.Write(p)
+	}
+	if err == nil {
+		for i, n := range r.counts {
+			l.counts[i].Add(uint64(n))
+		}
+	}
 	return err
 }
 
-func (l *Logger) Debug(v ...any) {
-	l.output(DEBUG, 0, 2, func(b []byte) []byte {
-		return fmt.Appendln(b, v...)
-	})
+func (l *Logger) appendArgs(b []byte, e *entry, v []any) []byte {
+	v = l.styleDurations(resolveLazy(v))
+	b = fmt.Appendln(b, v...)
+	for _, a := range v {
+		err, ok := a.(error)
+		if !ok {
+			continue
+		}
+		var lf LogFielder
+		if errors.As(err, &lf) {
+			b = l.appendFields(b[:len(b)-1], e, lf.LogFields())
+			b = append(b, '\n')
+		}
+	}
+	return b
 }
 
 func (l *Logger) Info(v ...any) {
-	l.output(INFO, 0, 2, func(b []byte) []byte {
-		return fmt.Appendln(b, v...)
+	l.output(INFO, 0, 2, func(b []byte, e *entry) []byte {
+		return l.appendArgs(b, e, v)
 	})
 }
 
 func (l *Logger) Error(v ...any) {
-	l.output(ERROR, 0, 2, func(b []byte) []byte {
-		return fmt.Appendln(b, v...)
+	l.output(ERROR, 0, 2, func(b []byte, e *entry) []byte {
+		return l.appendArgs(b, e, v)
 	})
 }
 
+// InfoTTL logs at INFO with an expires_at field, the record's timestamp
+// plus d in RFC 3339 UTC, so consumers can drop the record once it has
+// expired.
+func (l *Logger) InfoTTL(d time.Duration, v ...any) {
+	l.output(INFO, 0, 2, func(b []byte, e *entry) []byte {
+		expires := e.time.Add(d).UTC().Format(time.RFC3339Nano)
+		b = l.appendArgs(b, e, v)
+		b = l.appendFields(b[:len(b)-1], e, []any{"expires_at", expires})
+		return append(b, '\n')
+	})
+}
+
+// SetMaxBufferReuse sets the largest formatting buffer, in bytes, that this
+// logger returns to the shared pool; larger buffers are dropped after use.
+// n <= 0 restores the default of 64KB.
+func (l *Logger) SetMaxBufferReuse(n int) {
+	if n < 0 {
+		n = 0
+	}
+	l.maxBufReuse.Store(int64(n))
+}
+
+func (l *Logger) maxBufferReuse() int {
+	if n := l.maxBufReuse.Load(); n > 0 {
+		return int(n)
+	}
+	return defaultMaxBufferReuse
+}
+
+// SetHeaderStyle selects the record layout: the positional header (the
+// default), a logfmt-style line in which the flag-selected fields, the
+// message and the record's key/value fields are written as top-level
+// key=value pairs, or CSV rows.
+func (l *Logger) SetHeaderStyle(style HeaderStyle) {
+	l.headerStyle.Store(int32(style))
+}
+
+// SetSchemaVersion adds schema=<v> after the level in HeaderKeyValue
+// records, so that consumers can tell revisions of the record layout apart.
+// The positional and CSV styles are unaffected. An empty v, the default,
+// omits it.
+func (l *Logger) SetSchemaVersion(v string) {
+	if v == "" {
+		l.schema.Store(nil)
+		return
+	}
+	l.schema.Store(&v)
+}
+
+// SetNumericLevel makes the HeaderKeyValue and HeaderCSV styles write the
+// level as its syslog severity, 3 for ERROR, 6 for INFO and 7 for DEBUG,
+// instead of its name. The positional header keeps the label.
+func (l *Logger) SetNumericLevel(numeric bool) {
+	l.numericLevel.Store(numeric)
+}
+
+// SetElidePrefix makes the positional header blank out the prefix while it
+// is unchanged from the previous record, printing it again after SetPrefix.
+// It is meant for interactive terminals; leave it off for files and for the
+// HeaderKeyValue style, which always includes the prefix.
+func (l *Logger) SetElidePrefix(elide bool) {
+	l.elidePrefix.Store(elide)
+	l.lastPrefix.Store(nil)
+}
+
 func (l *Logger) Flags() int {
 	return int(l.flag.Load())
 }
 
+// SetFlags may be called at any time, including while other goroutines are
+// logging. Flags are read once per record, so turning on Lshortfile or
+// Llongfile enables caller lookup from the next record on.
 func (l *Logger) SetFlags(flag int) {
 	l.flag.Store(int32(flag))
 }
 
+// SetTimeZone renders record times in loc regardless of the host's local
+// zone, taking precedence over LUTC. A nil loc reverts to local time or
+// LUTC.
+func (l *Logger) SetTimeZone(loc *time.Location) {
+	l.loc.Store(loc)
+}
+
 func (l *Logger) Prefix() string {
-	if p := l.prefix.Load(); p != nil {
-		return *p
+	return l.prefixHeader().prefix
+}
+
+func (l *Logger) prefixHeader() *prefixHeader {
+	if h := l.prefix.Load(); h != nil {
+		return h
 	}
-	return ""
+	return emptyPrefixHeader
 }
 
 func (l *Logger) SetPrefix(prefix string) {
-	l.prefix.Store(&prefix)
+	l.prefix.Store(newPrefixHeader(prefix))
 }
 
 func (l *Logger) Level() Level {
@@ -246,8 +1044,30 @@ func (l *Logger) SetLevel(level Level) {
 	l.minLevel.Store(int32(level))
 }
 
+// SetLevelFilter switches the logger from threshold filtering to an explicit
+// allow-list: only the given levels are emitted. While a filter is set it
+// takes precedence and the SetLevel threshold is ignored.
+func (l *Logger) SetLevelFilter(levels ...Level) {
+	var f [256]bool
+	for _, level := range levels {
+		f[level] = true
+	}
+	l.levelFilter.Store(&f)
+}
+
+// ClearLevelFilter reverts to threshold filtering using the SetLevel value.
+func (l *Logger) ClearLevelFilter() {
+	l.levelFilter.Store(nil)
+}
+
+// BytesWritten reports the number of bytes successfully written to the
+// current output. SetOutput resets the count to zero.
+func (l *Logger) BytesWritten() int64 {
+	return l.written.Load()
+}
+
 func (l *Logger) Writer() io.Writer {
 	l.outMu.Lock()
 	defer l.outMu.Unlock()
-	return l.out
+	return l.writer()
 }
@@ -0,0 +1,581 @@
+package mylog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testTime = time.Date(2009, time.January, 23, 1, 23, 23, 123456789, time.UTC)
+
+// testClock is a settable clock for SetClock.
+type testClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *testClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// newTestLogger returns a DEBUG logger writing to a buffer, with its clock
+// fixed at testTime.
+func newTestLogger(flag int) (*Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := New(&buf, "", flag, DEBUG)
+	l.SetClock(func() time.Time { return testTime })
+	return l, &buf
+}
+
+func newClockLogger(flag int) (*Logger, *bytes.Buffer, *testClock) {
+	l, buf := newTestLogger(flag)
+	c := &testClock{t: testTime}
+	l.SetClock(c.now)
+	return l, buf, c
+}
+
+func lines(buf *bytes.Buffer) []string {
+	s := strings.TrimSuffix(buf.String(), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// captureStderr returns what f writes to os.Stderr.
+func captureStderr(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = old }()
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+	f()
+	w.Close()
+	return <-done
+}
+
+// thisLine returns the line number of its caller.
+func thisLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestOutput(t *testing.T) {
+	l, buf := newTestLogger(LstdFlags | LUTC)
+	l.Info("hello", 42)
+	l.Error("boom")
+	want := "[INFO]  2009/01/23 01:23:23 hello 42\n" +
+		"[ERROR] 2009/01/23 01:23:23 boom\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestShortfile(t *testing.T) {
+	l, buf := newTestLogger(Lshortfile)
+	line := thisLine() + 1
+	l.Info("hello")
+	want := fmt.Sprintf("[INFO]  log_test.go:%d: hello\n", line)
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetLevel(ERROR)
+	l.Info("hidden")
+	l.Error("shown")
+	if got := buf.String(); got != "[ERROR] shown\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestLevelFilter(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetLevel(ERROR)
+	l.SetLevelFilter(INFO)
+	l.Info("i")
+	l.Error("e")
+	if got, want := buf.String(), "[INFO]  i\n"; got != want {
+		t.Errorf("with filter: got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	l.ClearLevelFilter()
+	l.Info("i")
+	l.Error("e")
+	if got, want := buf.String(), "[ERROR] e\n"; got != want {
+		t.Errorf("after ClearLevelFilter: got %q, want %q", got, want)
+	}
+}
+
+func TestBytesWritten(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.Info("hello")
+	l.Error("world")
+	if got := l.BytesWritten(); got != int64(buf.Len()) {
+		t.Errorf("BytesWritten = %d, want %d", got, buf.Len())
+	}
+	l.SetOutput(io.Discard)
+	if got := l.BytesWritten(); got != 0 {
+		t.Errorf("BytesWritten after SetOutput = %d, want 0", got)
+	}
+}
+
+func TestPrefixSpacing(t *testing.T) {
+	tests := []struct {
+		prefix, want string
+	}{
+		{"", "[INFO]  msg\n"},
+		{"app", "app [INFO]  msg\n"},
+		{"app ", "app [INFO]  msg\n"},
+		{"app: ", "app: [INFO]  msg\n"},
+	}
+	for _, tt := range tests {
+		l, buf := newTestLogger(0)
+		l.SetPrefix(tt.prefix)
+		l.Info("msg")
+		if got := buf.String(); got != tt.want {
+			t.Errorf("prefix %q: got %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestSetWriterFunc(t *testing.T) {
+	l, orig := newTestLogger(0)
+	var a, b bytes.Buffer
+	var cur io.Writer = &a
+	l.SetWriterFunc(func() io.Writer { return cur })
+	l.Info("one")
+	cur = &b
+	l.Info("two")
+	cur = nil
+	l.Info("three")
+	if a.String() != "[INFO]  one\n" || b.String() != "[INFO]  two\n" || orig.Len() != 0 {
+		t.Errorf("a = %q, b = %q, original = %q", a.String(), b.String(), orig.String())
+	}
+
+	l.SetOutput(orig)
+	l.Info("four")
+	if orig.String() != "[INFO]  four\n" {
+		t.Errorf("after SetOutput: %q", orig.String())
+	}
+}
+
+func TestSchemaVersion(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetSchemaVersion("2")
+	l.Info("positional")
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.Info("kv")
+	l.SetSchemaVersion("")
+	l.Info("kv")
+	want := "[INFO]  positional\n" +
+		"level=INFO schema=2 msg=kv\n" +
+		"level=INFO msg=kv\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMaxBufferReuse(t *testing.T) {
+	l, _ := newTestLogger(0)
+	if got := l.maxBufferReuse(); got != defaultMaxBufferReuse {
+		t.Errorf("default = %d, want %d", got, defaultMaxBufferReuse)
+	}
+	l.SetMaxBufferReuse(128)
+	if got := l.maxBufferReuse(); got != 128 {
+		t.Errorf("after SetMaxBufferReuse(128) = %d", got)
+	}
+	l.SetMaxBufferReuse(-1)
+	if got := l.maxBufferReuse(); got != defaultMaxBufferReuse {
+		t.Errorf("after SetMaxBufferReuse(-1) = %d, want the default", got)
+	}
+
+	p := new([]byte)
+	*p = make([]byte, 0, 256)
+	putBuffer(p, 128)
+	if *p != nil {
+		t.Error("putBuffer kept a buffer above the cap")
+	}
+	// A new pointer, since p is already in the pool.
+	p = new([]byte)
+	*p = make([]byte, 0, 64)
+	putBuffer(p, 128)
+	if *p == nil {
+		t.Error("putBuffer dropped a buffer within the cap")
+	}
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPackage(t *testing.T) {
+	l, buf := newTestLogger(Lpackage)
+	l.Info("a")
+	l.SetFlags(Lpackage | Lshortfile)
+	line := thisLine() + 1
+	l.Info("b")
+	want := "[INFO]  github.com/moi-si/mylog: a\n" +
+		fmt.Sprintf("[INFO]  github.com/moi-si/mylog log_test.go:%d: b\n", line)
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.SetFlags(Lpackage)
+	l.Info("c")
+	if got, want := buf.String(), "level=INFO source=github.com/moi-si/mylog msg=c\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := []struct{ function, want string }{
+		{"main.main", "main"},
+		{"github.com/moi-si/mylog.(*Logger).Info", "github.com/moi-si/mylog"},
+		{"example.com/a.b/c.F.func1", "example.com/a.b/c"},
+		{"gopkg.in/yaml.v3.Marshal", "gopkg.in/yaml"},
+	}
+	for _, tt := range tests {
+		if got := packageName(tt.function); got != tt.want {
+			t.Errorf("packageName(%q) = %q, want %q", tt.function, got, tt.want)
+		}
+	}
+}
+
+func TestNumericLevel(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetNumericLevel(true)
+	l.Info("positional")
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.Info("i")
+	l.Error("e")
+	l.SetHeaderStyle(HeaderCSV)
+	l.Error("csv")
+	want := "[INFO]  positional\n" +
+		"level=6 msg=i\n" +
+		"level=3 msg=e\n" +
+		",3,,,csv,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestItoa(t *testing.T) {
+	tests := []struct {
+		i, wid int
+		want   string
+	}{
+		{0, -1, "0"},
+		{7, 2, "07"},
+		{-5, 2, "-05"},
+		{123, 2, "123"},
+		{math.MaxInt64, -1, "9223372036854775807"},
+		{math.MinInt64, -1, "-9223372036854775808"},
+		{1, 30, strings.Repeat("0", 19) + "1"},
+	}
+	for _, tt := range tests {
+		var b []byte
+		itoa(&b, tt.i, tt.wid)
+		if string(b) != tt.want {
+			t.Errorf("itoa(%d, %d) = %q, want %q", tt.i, tt.wid, b, tt.want)
+		}
+	}
+}
+
+var uptimeRecord = regexp.MustCompile(`^\[INFO\]  \+(\S+) up$`)
+
+func TestUptime(t *testing.T) {
+	// The fixed test clock must not affect the uptime.
+	l, buf := newTestLogger(Luptime)
+	l.Info("up")
+	time.Sleep(time.Millisecond)
+	l.Info("up")
+	var prev time.Duration
+	for _, line := range lines(buf) {
+		m := uptimeRecord.FindStringSubmatch(line)
+		if m == nil {
+			t.Fatalf("record %q has no uptime", line)
+		}
+		d, err := time.ParseDuration(m[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d <= prev || d > time.Since(processStart) {
+			t.Errorf("uptime %v not after %v", d, prev)
+		}
+		prev = d
+	}
+}
+
+func TestCounter(t *testing.T) {
+	l, buf := newTestLogger(Lcounter)
+	l.Info("a")
+	l.Error("b")
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.Info("c")
+	want := "[INFO]  #1 a\n[ERROR] #2 b\nlevel=INFO n=3 msg=c\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestElidePrefix(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetPrefix("app")
+	l.SetElidePrefix(true)
+	l.Info("a")
+	l.Error("b")
+	l.SetPrefix("app")
+	l.Info("c")
+	want := "app [INFO]  a\n    [ERROR] b\napp [INFO]  c\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestInfoTTL(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.InfoTTL(time.Minute, "cache", "warm")
+	if got, want := buf.String(), "[INFO]  cache warm expires_at=2009-01-23T01:24:23.123456789Z\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	l.SetHeaderStyle(HeaderCSV)
+	l.InfoTTL(time.Minute, "cache warm")
+	if got, want := buf.String(), ",INFO,,,cache warm,expires_at=2009-01-23T01:24:23.123456789Z\n"; got != want {
+		t.Errorf("CSV: got %q, want %q", got, want)
+	}
+}
+
+func TestSetFlagsDynamic(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.Info("a")
+	l.SetFlags(Lshortfile)
+	line := thisLine() + 1
+	l.Info("b")
+	want := fmt.Sprintf("[INFO]  a\n[INFO]  log_test.go:%d: b\n", line)
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSetFlagsConcurrent(t *testing.T) {
+	var out syncBuffer
+	l := New(&out, "", 0, INFO)
+	line := thisLine() + 7
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				l.Info("x")
+			}
+		}()
+	}
+	stop := make(chan struct{})
+	toggled := make(chan struct{})
+	go func() {
+		defer close(toggled)
+		for flag := Lshortfile; ; flag ^= Lshortfile {
+			select {
+			case <-stop:
+				return
+			default:
+				l.SetFlags(flag)
+			}
+		}
+	}()
+	wg.Wait()
+	close(stop)
+	<-toggled
+	with := fmt.Sprintf("[INFO]  log_test.go:%d: x", line)
+	for _, got := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+		if got != "[INFO]  x" && got != with {
+			t.Fatalf("record %q, want %q with or without the caller", got, with)
+		}
+	}
+}
+
+func TestEpoch(t *testing.T) {
+	l, buf := newTestLogger(LstdFlags | Lepoch)
+	l.Info("s")
+	l.SetFlags(Lepochmillis)
+	l.Info("ms")
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.Info("kv")
+	sec := strconv.FormatInt(testTime.Unix(), 10)
+	ms := strconv.FormatInt(testTime.UnixMilli(), 10)
+	want := "[INFO]  " + sec + " s\n[INFO]  " + ms + " ms\nts=" + ms + " level=INFO msg=kv\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTimeZone(t *testing.T) {
+	l, buf := newTestLogger(LstdFlags | LUTC)
+	l.SetTimeZone(time.FixedZone("IST", 5*3600+1800))
+	l.Info("ist")
+	l.SetTimeZone(nil)
+	l.Info("utc")
+	want := "[INFO]  2009/01/23 06:53:23 ist\n[INFO]  2009/01/23 01:23:23 utc\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// asyncWriter keeps the slices it is given and reads them on another
+// goroutine after Write has returned.
+type asyncWriter struct {
+	ch chan []byte
+}
+
+func (w asyncWriter) Write(p []byte) (int, error) {
+	w.ch <- p
+	return len(p), nil
+}
+
+func TestCopyRecords(t *testing.T) {
+	w := asyncWriter{make(chan []byte, 1000)}
+	l := New(w, "", 0, DEBUG)
+	l.SetCopyRecords(true)
+
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				l.Info(g, i)
+			}
+		}()
+	}
+	seen := make(map[string]bool)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for p := range w.ch {
+			seen[string(p)] = true
+		}
+	}()
+	wg.Wait()
+	close(w.ch)
+	<-done
+
+	for g := range 4 {
+		for i := range 100 {
+			if rec := fmt.Sprintf("[INFO]  %d %d\n", g, i); !seen[rec] {
+				t.Fatalf("record %q missing or overwritten", rec)
+			}
+		}
+	}
+}
+
+func TestWithOutput(t *testing.T) {
+	l, buf := newTestLogger(0)
+	var scoped bytes.Buffer
+	l.WithOutput(&scoped, func() { l.Info("inside") })
+	l.Info("outside")
+	if scoped.String() != "[INFO]  inside\n" || buf.String() != "[INFO]  outside\n" {
+		t.Errorf("scoped = %q, main = %q", scoped.String(), buf.String())
+	}
+
+	func() {
+		defer func() { recover() }()
+		l.WithOutput(&scoped, func() { panic("boom") })
+	}()
+	if l.Writer() != buf {
+		t.Error("output not restored after a panic")
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	l, buf := newTestLogger(Lsortkey)
+	l.Info("a")
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.Info("b")
+	want := "[INFO]  2009-01-23T01:23:23.123456Z-00000000000000000001 a\n" +
+		"level=INFO sort=2009-01-23T01:23:23.123456Z-00000000000000000002 msg=b\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	var b []byte
+	appendSortKey(&b, testTime, math.MaxUint64)
+	if got := string(b); got != "2009-01-23T01:23:23.123456Z-18446744073709551615" {
+		t.Errorf("max counter = %q", got)
+	}
+}
+
+func BenchmarkInfo(b *testing.B) {
+	l := New(nopWriter{}, "", LstdFlags, INFO)
+	b.ReportAllocs()
+	for b.Loop() {
+		l.Info("hello", 42)
+	}
+}
+
+func BenchmarkInfoPrefix(b *testing.B) {
+	l := New(nopWriter{}, "service-name", LstdFlags, INFO)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Info("hello")
+		}
+	})
+}
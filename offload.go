@@ -0,0 +1,60 @@
+package mylog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"sync"
+)
+
+type fieldOffload struct {
+	threshold int
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// SetFieldOffload moves key/value field values whose rendered text is
+// longer than threshold bytes out of the record and into w. The record
+// keeps a reference to the value, blob:<id>, where id is the first 16 hex
+// digits of the SHA-256 of the value, and w receives one write per value:
+//
+//	blob:<id> <length>\n<value>\n
+//
+// with the value's length in bytes, so values may contain newlines. Values
+// are written to w as their record is formatted, even while the logger is
+// paused. If the write fails, the value stays in the record. The slog
+// mirror still gets the full value. A threshold
+// <= 0 or a nil w turns offloading off.
+func (l *Logger) SetFieldOffload(threshold int, w io.Writer) {
+	if threshold <= 0 || w == nil {
+		l.offload.Store(nil)
+		return
+	}
+	l.offload.Store(&fieldOffload{threshold: threshold, w: w})
+}
+
+// store writes text to the side writer if it is over the threshold and
+// returns the text to put in the record.
+func (o *fieldOffload) store(text string) string {
+	if o == nil || len(text) <= o.threshold {
+		return text
+	}
+	sum := sha256.Sum256([]byte(text))
+	ref := "blob:" + hex.EncodeToString(sum[:8])
+	b := make([]byte, 0, len(ref)+len(text)+24)
+	b = append(b, ref...)
+	b = append(b, ' ')
+	b = strconv.AppendInt(b, int64(len(text)), 10)
+	b = append(b, '\n')
+	b = append(b, text...)
+	b = append(b, '\n')
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, err := o.w.Write(b); err != nil {
+		return text
+	}
+	return ref
+}
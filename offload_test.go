@@ -0,0 +1,46 @@
+package mylog
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type failWriter struct{}
+
+func (failWriter) Write(p []byte) (int, error) { return 0, errors.New("side writer failed") }
+
+func TestFieldOffload(t *testing.T) {
+	l, buf := newTestLogger(0)
+	var side bytes.Buffer
+	l.SetFieldOffload(8, &side)
+	body := strings.Repeat("x", 20) + "\nend"
+	l.Event("request", "body", body, "id", 7)
+	sum := sha256.Sum256([]byte(body))
+	ref := "blob:" + hex.EncodeToString(sum[:8])
+	if got, want := buf.String(), "[INFO]  event=request body="+ref+" id=7\n"; got != want {
+		t.Errorf("record = %q, want %q", got, want)
+	}
+	if got, want := side.String(), fmt.Sprintf("%s %d\n%s\n", ref, len(body), body); got != want {
+		t.Errorf("side = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	l.SetFieldOffload(8, failWriter{})
+	l.Event("request", "body", "0123456789")
+	if got, want := buf.String(), "[INFO]  event=request body=0123456789\n"; got != want {
+		t.Errorf("after a failed side write: got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	side.Reset()
+	l.SetFieldOffload(0, &side)
+	l.Event("request", "body", "0123456789")
+	if got, want := buf.String(), "[INFO]  event=request body=0123456789\n"; got != want || side.Len() != 0 {
+		t.Errorf("offload off: got %q, side %q", got, side.String())
+	}
+}
@@ -0,0 +1,32 @@
+package mylog
+
+import "reflect"
+
+// SetOmitEmptyFields makes key/value fields, such as those from LogFields
+// and Event, skip empty values: nil, nil pointers, interfaces and funcs,
+// empty strings, zero numbers, and empty slices and maps. False booleans
+// and zero structs are kept. Lazy values are checked after they are
+// resolved. InfoChanged always writes its value.
+func (l *Logger) SetOmitEmptyFields(omit bool) {
+	l.omitEmpty.Store(omit)
+}
+
+func isEmptyField(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.Len() == 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return rv.IsZero()
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
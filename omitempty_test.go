@@ -0,0 +1,24 @@
+package mylog
+
+import "testing"
+
+func TestOmitEmptyFields(t *testing.T) {
+	l, buf := newTestLogger(0)
+	var nilPtr *int
+	kv := []any{
+		"s", "", "n", 0, "f", 0.0, "nil", nil, "ptr", nilPtr, "slice", []int{}, "map", map[string]int{},
+		"lazy", Lazy(func() any { return "" }),
+		"b", false, "st", struct{}{}, "keep", "x",
+	}
+	l.SetOmitEmptyFields(true)
+	l.Event("e", kv...)
+	l.InfoChanged("count", 0)
+	l.SetOmitEmptyFields(false)
+	l.Event("e", "s", "", "n", 0)
+	want := "[INFO]  event=e b=false st={} keep=x\n" +
+		"[INFO]  count=0\n" +
+		"[INFO]  event=e s=\"\" n=0\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
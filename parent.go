@@ -0,0 +1,58 @@
+package mylog
+
+import "errors"
+
+var errLoggerCycle = errors.New("mylog: SetParent would create a cycle")
+
+type parentSink struct {
+	l *Logger
+}
+
+func (s parentSink) Write(p []byte) (int, error) {
+	return s.WriteLevel(INFO, p)
+}
+
+func (s parentSink) WriteLevel(level Level, p []byte) (int, error) {
+	return len(p), s.l.forward(level, p)
+}
+
+// SetParent makes parent the output of l. Each formatted record from l is
+// passed through parent's level filter, using the record's level, and
+// written to parent's output with parent's prefix in front; parent does not
+// add a second level label or timestamp. SetParent returns an error, and
+// changes nothing, if parent already forwards to l.
+func (l *Logger) SetParent(parent *Logger) error {
+	for p := parent; p != nil; {
+		if p == l {
+			return errLoggerCycle
+		}
+		p.outMu.Lock()
+		s, ok := p.out.(parentSink)
+		p.outMu.Unlock()
+		if !ok {
+			break
+		}
+		p = s.l
+	}
+	l.SetOutput(parentSink{parent})
+	return nil
+}
+
+func (l *Logger) forward(level Level, p []byte) error {
+	if !l.enabled(level) || l.isDiscard.Load() {
+		return nil
+	}
+	defer l.mirrorLine(level, p)
+	prefix := l.Prefix()
+	if prefix == "" {
+		return l.write(level, p)
+	}
+	buf := getBuffer()
+	defer putBuffer(buf, l.maxBufferReuse())
+	*buf = append(*buf, prefix...)
+	if prefix[len(prefix)-1] != ' ' {
+		*buf = append(*buf, ' ')
+	}
+	*buf = append(*buf, p...)
+	return l.write(level, *buf)
+}
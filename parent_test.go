@@ -0,0 +1,42 @@
+package mylog
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetParent(t *testing.T) {
+	parent, buf := newTestLogger(0)
+	parent.SetPrefix("app")
+	child, _ := newTestLogger(0)
+	child.SetPrefix("db")
+	if err := child.SetParent(parent); err != nil {
+		t.Fatal(err)
+	}
+	child.Info("query")
+	parent.SetLevel(ERROR)
+	child.Info("filtered by the parent")
+	child.Error("failed")
+	want := "app db [INFO]  query\napp db [ERROR] failed\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSetParentCycle(t *testing.T) {
+	a, _ := newTestLogger(0)
+	b, _ := newTestLogger(0)
+	c, _ := newTestLogger(0)
+	if err := b.SetParent(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetParent(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.SetParent(c); !errors.Is(err, errLoggerCycle) {
+		t.Errorf("SetParent cycle = %v", err)
+	}
+	if err := a.SetParent(a); !errors.Is(err, errLoggerCycle) {
+		t.Errorf("SetParent(self) = %v", err)
+	}
+}
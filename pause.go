@@ -0,0 +1,60 @@
+package mylog
+
+import "slices"
+
+const defaultPauseLimit = 1000
+
+// Pause holds records in memory instead of writing them until Resume is
+// called. At most SetPauseLimit records are kept; once full, the oldest is
+// dropped and counted in Dropped. Unlike SetOutput(io.Discard), nothing is
+// lost unless the buffer overflows.
+func (l *Logger) Pause() {
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	l.paused = true
+}
+
+// Resume writes the records held since Pause, in order, and returns to
+// writing records directly. It returns the first write error.
+func (l *Logger) Resume() error {
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	l.paused = false
+	return l.flushHeld()
+}
+
+// SetPauseLimit sets how many records Pause holds; n <= 0 restores the
+// default of 1000.
+func (l *Logger) SetPauseLimit(n int) {
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	l.pauseLimit = n
+}
+
+// hold must be called with outMu held.
+func (l *Logger) hold(r heldRecord) {
+	limit := l.pauseLimit
+	if limit <= 0 {
+		limit = defaultPauseLimit
+	}
+	if len(l.pending) >= limit {
+		n := len(l.pending) - limit + 1
+		l.pending = slices.Delete(l.pending, 0, n)
+		l.dropped.Add(uint64(n))
+	}
+	r.p = slices.Clone(r.p)
+	r.fields = slices.Clone(r.fields)
+	l.pending = append(l.pending, r)
+}
+
+// flushHeld must be called with outMu held.
+func (l *Logger) flushHeld() error {
+	var first error
+	for _, r := range l.pending {
+		if err := l.writeLocked(r); err != nil && first == nil {
+			first = err
+		}
+	}
+	l.pending = nil
+	return first
+}
@@ -0,0 +1,37 @@
+package mylog
+
+import "testing"
+
+func TestPauseResume(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.Pause()
+	l.Info("a")
+	l.Error("b")
+	if buf.Len() != 0 {
+		t.Fatalf("written while paused: %q", buf.String())
+	}
+	if got := l.Explain(INFO); got != "held: logger is paused until Resume" {
+		t.Errorf("Explain while paused = %q", got)
+	}
+	if err := l.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("c")
+	if got, want := buf.String(), "[INFO]  a\n[ERROR] b\n[INFO]  c\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	l.SetPauseLimit(2)
+	l.Pause()
+	for i := range 5 {
+		l.Info(i)
+	}
+	if got := l.Dropped(); got != 3 {
+		t.Errorf("Dropped = %d, want 3", got)
+	}
+	l.Close()
+	if got, want := buf.String(), "[INFO]  3\n[INFO]  4\n"; got != want {
+		t.Errorf("Close flushed %q, want %q", got, want)
+	}
+}
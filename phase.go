@@ -0,0 +1,56 @@
+package mylog
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// Phase is a timed section of work started by Logger.Begin.
+type Phase struct {
+	l       *Logger
+	name    string
+	start   time.Time
+	cleanup runtime.Cleanup
+	ended   atomic.Bool
+}
+
+type leakedPhase struct {
+	l    *Logger
+	name string
+	pc   uintptr
+}
+
+// Begin starts a phase; call End on the returned Phase to log its duration.
+// If the Phase is garbage collected without End being called, a warning is
+// logged at ERROR, attributed to the Begin call.
+func (l *Logger) Begin(name string) *Phase {
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+	p := &Phase{l: l, name: name, start: l.now()}
+	p.cleanup = runtime.AddCleanup(p, func(lp leakedPhase) {
+		lp.l.output(ERROR, lp.pc, 0, func(b []byte, e *entry) []byte {
+			b = append(b, "phase="...)
+			appendValue(&b, lp.name)
+			return append(b, " leaked without End\n"...)
+		})
+	}, leakedPhase{l, name, pcs[0]})
+	return p
+}
+
+// End logs the phase name and elapsed time at INFO. Only the first call
+// has an effect.
+func (p *Phase) End() {
+	if p.ended.Swap(true) {
+		return
+	}
+	p.cleanup.Stop()
+	elapsed := p.l.now().Sub(p.start)
+	p.l.output(INFO, 0, 2, func(b []byte, e *entry) []byte {
+		b = append(b, "phase="...)
+		appendValue(&b, p.name)
+		b = append(b, " elapsed="...)
+		b = append(b, elapsed.String()...)
+		return append(b, '\n')
+	})
+}
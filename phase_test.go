@@ -0,0 +1,34 @@
+package mylog
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestPhase(t *testing.T) {
+	l, buf, clock := newClockLogger(0)
+	p := l.Begin("load config")
+	clock.advance(1500 * time.Millisecond)
+	p.End()
+	p.End()
+	if got, want := buf.String(), "[INFO]  phase=\"load config\" elapsed=1.5s\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPhaseLeak(t *testing.T) {
+	var out syncBuffer
+	l := New(&out, "", Lshortfile, DEBUG)
+	line := thisLine() + 1
+	l.Begin("leaky")
+	waitFor(t, func() bool {
+		runtime.GC()
+		return out.String() != ""
+	})
+	want := fmt.Sprintf("[ERROR] phase_test.go:%d: phase=leaky leaked without End\n", line)
+	if got := out.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+package mylog
+
+import (
+	"sync"
+	"time"
+)
+
+type burstAlert struct {
+	rate     int
+	window   time.Duration
+	callback func(count int)
+
+	mu    sync.Mutex
+	start time.Time
+	count int
+	fired bool
+}
+
+func (b *burstAlert) record(now time.Time) {
+	b.mu.Lock()
+	if now.Sub(b.start) >= b.window {
+		b.start = now
+		b.count = 0
+		b.fired = false
+	}
+	b.count++
+	fire := !b.fired && b.count > b.rate
+	if fire {
+		b.fired = true
+	}
+	count := b.count
+	b.mu.Unlock()
+
+	if fire {
+		b.callback(count)
+	}
+}
+
+type lineLimiter struct {
+	rate float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func (r *lineLimiter) allow(now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last.IsZero() {
+		r.tokens = r.rate
+	} else if elapsed := now.Sub(r.last); elapsed > 0 {
+		r.tokens = min(r.rate, r.tokens+elapsed.Seconds()*r.rate)
+	}
+	r.last = now
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+// SetMaxLinesPerSecond caps the logger at n records per second using a token
+// bucket that allows bursts of up to n. Records over the cap are dropped and
+// counted in Dropped. The cap applies after level filtering. n <= 0 removes
+// the cap.
+func (l *Logger) SetMaxLinesPerSecond(n int) {
+	if n <= 0 {
+		l.limiter.Store(nil)
+		return
+	}
+	l.limiter.Store(&lineLimiter{rate: float64(n)})
+}
+
+// Dropped reports how many records have been discarded by the line rate cap
+// or because the Pause buffer was full.
+func (l *Logger) Dropped() uint64 {
+	return l.dropped.Load()
+}
+
+// SetBurstAlert calls callback once per window when more than rate ERROR
+// records are logged within that window. The callback runs synchronously on
+// the logging goroutine. A nil callback or non-positive window disables it.
+func (l *Logger) SetBurstAlert(rate int, window time.Duration, callback func(count int)) {
+	if callback == nil || window <= 0 {
+		l.burst.Store(nil)
+		return
+	}
+	l.burst.Store(&burstAlert{rate: rate, window: window, callback: callback})
+}
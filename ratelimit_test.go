@@ -0,0 +1,45 @@
+package mylog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBurstAlert(t *testing.T) {
+	l, _, clock := newClockLogger(0)
+	var fired []int
+	l.SetBurstAlert(2, time.Second, func(n int) { fired = append(fired, n) })
+	for range 4 {
+		l.Error("e")
+	}
+	l.Info("not counted")
+	if len(fired) != 1 || fired[0] != 3 {
+		t.Fatalf("fired = %v, want [3]", fired)
+	}
+	clock.advance(time.Second)
+	for range 3 {
+		l.Error("e")
+	}
+	if len(fired) != 2 {
+		t.Errorf("fired = %v, want a second alert in the next window", fired)
+	}
+}
+
+func TestMaxLinesPerSecond(t *testing.T) {
+	l, buf, clock := newClockLogger(0)
+	l.SetMaxLinesPerSecond(2)
+	for i := range 3 {
+		l.Info(i)
+	}
+	if got := l.Dropped(); got != 1 {
+		t.Errorf("Dropped = %d, want 1", got)
+	}
+	clock.advance(time.Second)
+	l.Info("refilled")
+	l.SetMaxLinesPerSecond(0)
+	l.Info("uncapped")
+	want := "[INFO]  0\n[INFO]  1\n[INFO]  refilled\n[INFO]  uncapped\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,43 @@
+package mylog
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+)
+
+var recordIDState atomic.Uint64
+
+// appendRecordID appends a UUIDv7 for t. The 12-bit rand_a field holds a
+// sequence so IDs are strictly increasing within the process, even when many
+// records share a millisecond.
+func appendRecordID(buf *[]byte, t time.Time) {
+	ms := uint64(t.UnixMilli())
+	var state uint64
+	for {
+		last := recordIDState.Load()
+		state = ms << 12
+		if state <= last {
+			state = last + 1
+		}
+		if recordIDState.CompareAndSwap(last, state) {
+			break
+		}
+	}
+
+	var id [16]byte
+	hi := (state>>12)<<16 | 0x7000 | state&0xfff
+	lo := rand.Uint64()&(1<<62-1) | 1<<63
+	for i := 0; i < 8; i++ {
+		id[i] = byte(hi >> (56 - 8*i))
+		id[8+i] = byte(lo >> (56 - 8*i))
+	}
+
+	const hex = "0123456789abcdef"
+	for i, b := range id {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			*buf = append(*buf, '-')
+		}
+		*buf = append(*buf, hex[b>>4], hex[b&0x0f])
+	}
+}
@@ -0,0 +1,29 @@
+package mylog
+
+import (
+	"regexp"
+	"testing"
+)
+
+var uuidV7 = regexp.MustCompile(`^\[INFO\]  ([0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}) r$`)
+
+func TestRecordID(t *testing.T) {
+	l, buf := newTestLogger(Lrecordid)
+	for range 100 {
+		l.Info("r")
+	}
+	prev := ""
+	for _, line := range lines(buf) {
+		m := uuidV7.FindStringSubmatch(line)
+		if m == nil {
+			t.Fatalf("record %q has no UUIDv7", line)
+		}
+		// All records share a millisecond, so ordering comes from the
+		// sequence in the first 64 bits.
+		if hi := m[1][:18]; hi <= prev {
+			t.Fatalf("record ID %s not after %s", hi, prev)
+		} else {
+			prev = hi
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package mylog
+
+import (
+	"io"
+	"slices"
+)
+
+// field is a key/value field as written to a record, with text its
+// rendered value.
+type field struct {
+	key   string
+	value any
+	text  string
+}
+
+type fieldRoute struct {
+	key, value string
+	w          io.Writer
+}
+
+// AddFieldRouter additionally writes every record that has a key/value
+// field key whose rendered value is value, such as category=billing from
+// Event or LogFields, to w. Several routers may be added, and a record is
+// written to each router it matches, even if two routers share a writer.
+// Routed copies are held by Pause like the regular output, and errors from
+// w are ignored.
+func (l *Logger) AddFieldRouter(key, value string, w io.Writer) {
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	var routes []fieldRoute
+	if old := l.routes.Load(); old != nil {
+		routes = slices.Clone(*old)
+	}
+	routes = append(routes, fieldRoute{key, value, w})
+	l.routes.Store(&routes)
+	l.updateDiscard()
+}
+
+// matchRoutes returns the writers of the routes matching fields.
+func (l *Logger) matchRoutes(fields []field) []io.Writer {
+	routes := l.routes.Load()
+	if routes == nil || len(fields) == 0 {
+		return nil
+	}
+	var ws []io.Writer
+	for _, r := range *routes {
+		for _, f := range fields {
+			if f.key == r.key && f.text == r.value {
+				ws = append(ws, r.w)
+				break
+			}
+		}
+	}
+	return ws
+}
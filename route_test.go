@@ -0,0 +1,65 @@
+package mylog
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestFieldRouter(t *testing.T) {
+	l, buf := newTestLogger(0)
+	var billing, audit bytes.Buffer
+	l.AddFieldRouter("category", "billing", &billing)
+	l.AddFieldRouter("audit", "true", &audit)
+	l.AddFieldRouter("audit", "true", &audit)
+	l.Event("charge", "category", "billing", "audit", true)
+	l.Event("login", "category", "auth")
+	l.Info("category", "billing")
+
+	main := "[INFO]  event=charge category=billing audit=true\n" +
+		"[INFO]  event=login category=auth\n" +
+		"[INFO]  category billing\n"
+	if got := buf.String(); got != main {
+		t.Errorf("main = %q, want %q", got, main)
+	}
+	if got, want := billing.String(), "[INFO]  event=charge category=billing audit=true\n"; got != want {
+		t.Errorf("billing = %q, want %q", got, want)
+	}
+	if got, want := audit.String(), "[INFO]  event=charge category=billing audit=true\n"+
+		"[INFO]  event=charge category=billing audit=true\n"; got != want {
+		t.Errorf("audit = %q, want the record once per router", got)
+	}
+}
+
+type categoryError string
+
+func (e categoryError) Error() string    { return "charge failed" }
+func (e categoryError) LogFields() []any { return []any{"category", string(e)} }
+
+func TestFieldRouterPauseAndBatch(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetOutput(io.Discard)
+	var billing bytes.Buffer
+	l.AddFieldRouter("category", "billing", &billing)
+	l.Pause()
+	l.Event("charge", "category", "billing")
+	if billing.Len() != 0 {
+		t.Errorf("routed while paused: %q", billing.String())
+	}
+	l.Resume()
+
+	b := l.Batch()
+	b.Add(INFO, categoryError("billing"))
+	b.Add(INFO, "unrouted")
+	b.Commit()
+	l.Event("refund", "category", "billing")
+	want := "[INFO]  event=charge category=billing\n" +
+		"[INFO]  charge failed category=billing\n" +
+		"[INFO]  event=refund category=billing\n"
+	if got := billing.String(); got != want {
+		t.Errorf("billing = %q, want %q", got, want)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("main output written: %q", buf.String())
+	}
+}
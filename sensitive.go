@@ -0,0 +1,50 @@
+package mylog
+
+import "strings"
+
+// KeyMatch selects how SetSensitiveKeys compares field keys.
+type KeyMatch uint8
+
+const (
+	// MatchExact masks keys equal to one of the sensitive keys.
+	MatchExact KeyMatch = iota
+	// MatchContains masks keys containing one of the sensitive keys, so
+	// that "key" also covers "api_key".
+	MatchContains
+)
+
+const maskedValue = "***"
+
+type sensitiveKeys struct {
+	match KeyMatch
+	keys  []string
+}
+
+// SetSensitiveKeys replaces the values of key/value fields whose keys match
+// one of keys with "***", in every header style. Keys are compared without
+// regard to case. Calling it with no keys removes the masking.
+func (l *Logger) SetSensitiveKeys(match KeyMatch, keys ...string) {
+	if len(keys) == 0 {
+		l.sensitive.Store(nil)
+		return
+	}
+	s := &sensitiveKeys{match: match}
+	for _, k := range keys {
+		s.keys = append(s.keys, strings.ToLower(k))
+	}
+	l.sensitive.Store(s)
+}
+
+// masks reports whether the value of the field with key k must be masked.
+func (s *sensitiveKeys) masks(k string) bool {
+	if s == nil {
+		return false
+	}
+	k = strings.ToLower(k)
+	for _, sk := range s.keys {
+		if k == sk || s.match == MatchContains && strings.Contains(k, sk) {
+			return true
+		}
+	}
+	return false
+}
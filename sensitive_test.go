@@ -0,0 +1,32 @@
+package mylog
+
+import "testing"
+
+func TestSensitiveKeys(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetSensitiveKeys(MatchExact, "Password")
+	l.Event("login", "user", "bob", "password", "hunter2", "password_hint", "pet")
+	l.SetSensitiveKeys(MatchContains, "key", "token")
+	l.Event("call", "api_key", "abc", "X-Token", "def", "keyboard", "us")
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.Event("kv", "token", "ghi")
+	l.SetSensitiveKeys(MatchExact)
+	l.SetHeaderStyle(HeaderPositional)
+	l.Event("off", "token", "ghi")
+	want := "[INFO]  event=login user=bob password=*** password_hint=pet\n" +
+		"[INFO]  event=call api_key=*** X-Token=*** keyboard=***\n" +
+		"level=INFO event=kv token=***\n" +
+		"[INFO]  event=off token=ghi\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSensitiveKeysFromErrors(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetSensitiveKeys(MatchExact, "id")
+	l.Error(fieldError{})
+	if got, want := buf.String(), "[ERROR] not found id=*** table=users\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+package mylog
+
+const separatorWidth = 40
+
+// Separator writes a line of '=' without the usual header. Like Section it is
+// filtered as an INFO record.
+func (l *Logger) Separator() {
+	if !l.enabled(INFO) || l.isDiscard.Load() {
+		return
+	}
+	buf := getBuffer()
+	defer putBuffer(buf, l.maxBufferReuse())
+	for i := 0; i < separatorWidth; i++ {
+		*buf = append(*buf, '=')
+	}
+	*buf = append(*buf, '\n')
+	l.write(INFO, *buf)
+	l.mirrorLine(INFO, *buf)
+}
+
+// Section writes a header-less marker line such as "===== title =====".
+func (l *Logger) Section(title string) {
+	if !l.enabled(INFO) || l.isDiscard.Load() {
+		return
+	}
+	buf := getBuffer()
+	defer putBuffer(buf, l.maxBufferReuse())
+	*buf = append(*buf, "===== "...)
+	*buf = append(*buf, title...)
+	*buf = append(*buf, " =====\n"...)
+	l.write(INFO, *buf)
+	l.mirrorLine(INFO, *buf)
+}
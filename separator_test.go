@@ -0,0 +1,25 @@
+package mylog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSeparatorSection(t *testing.T) {
+	l, buf := newTestLogger(LstdFlags)
+	l.SetPrefix("app")
+	l.Separator()
+	l.Section("setup")
+	want := strings.Repeat("=", 40) + "\n===== setup =====\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	l.SetLevel(ERROR)
+	l.Separator()
+	l.Section("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("filtered as INFO: got %q", buf.String())
+	}
+}
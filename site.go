@@ -0,0 +1,82 @@
+package mylog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type siteLimit struct {
+	n      int
+	window time.Duration
+
+	mu    sync.Mutex
+	sites map[uintptr]*siteState
+}
+
+type siteState struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+// allow reports whether a record from pc may be written and how many
+// records from pc were suppressed in the window that just ended.
+func (s *siteLimit) allow(pc uintptr, now time.Time) (ok bool, suppressed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.sites[pc]
+	if st == nil {
+		st = &siteState{start: now}
+		s.sites[pc] = st
+	} else if now.Sub(st.start) >= s.window {
+		suppressed = st.suppressed
+		*st = siteState{start: now}
+	}
+	if st.count >= s.n {
+		st.suppressed++
+		return false, suppressed
+	}
+	st.count++
+	return true, suppressed
+}
+
+// capped reports how many sites have reached the cap in their current
+// window.
+func (s *siteLimit) capped(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, st := range s.sites {
+		if st.count >= s.n && now.Sub(st.start) < s.window {
+			n++
+		}
+	}
+	return n
+}
+
+// SetCallerSiteLimit caps each call site at n records per window. Further
+// records from that site are dropped and counted in Dropped. The next
+// record from the site in a later window is preceded by a record saying how
+// many were suppressed. Sites are told apart by program counter, which is
+// looked up even when no caller flags are set. n <= 0 removes the cap.
+func (l *Logger) SetCallerSiteLimit(n int, window time.Duration) {
+	if n <= 0 || window <= 0 {
+		l.siteLimit.Store(nil)
+		return
+	}
+	l.siteLimit.Store(&siteLimit{n: n, window: window, sites: make(map[uintptr]*siteState)})
+}
+
+func (l *Logger) checkSite(level Level, now time.Time, pc uintptr) bool {
+	ok, suppressed := l.siteLimit.Load().allow(pc, now)
+	if suppressed > 0 {
+		l.emit(level, now, pc, 0, false, func(b []byte, e *entry) []byte {
+			return fmt.Appendf(b, "suppressed %d records from this call site\n", suppressed)
+		})
+	}
+	if !ok {
+		l.dropped.Add(1)
+	}
+	return ok
+}
@@ -0,0 +1,43 @@
+package mylog
+
+import (
+	"testing"
+	"time"
+)
+
+// logLoop logs n records from one call site. It is not inlined, as each
+// inlined copy would be a site of its own.
+//
+//go:noinline
+func logLoop(l *Logger, n int) {
+	for i := range n {
+		l.Info("loop", i)
+	}
+}
+
+func TestCallerSiteLimit(t *testing.T) {
+	l, buf, clock := newClockLogger(0)
+	l.SetCallerSiteLimit(2, time.Second)
+	logLoop(l, 5)
+	l.Info("other site")
+	if got := l.Dropped(); got != 3 {
+		t.Errorf("Dropped = %d, want 3", got)
+	}
+	if got, want := l.Explain(INFO), "logged unless its call site is one of the 1 at the caller site limit"; got != want {
+		t.Errorf("Explain = %q, want %q", got, want)
+	}
+
+	clock.advance(time.Second)
+	logLoop(l, 1)
+	want := "[INFO]  loop 0\n[INFO]  loop 1\n[INFO]  other site\n" +
+		"[INFO]  suppressed 3 records from this call site\n[INFO]  loop 0\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	l.SetCallerSiteLimit(0, time.Second)
+	logLoop(l, 5)
+	if got := l.Explain(INFO); got != "logged" {
+		t.Errorf("Explain without a limit = %q", got)
+	}
+}
@@ -0,0 +1,154 @@
+package mylog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type mirrorKey struct{}
+
+// SetSlogMirror makes every record written by this logger also go to
+// slog.Default() at the matching slog level, with the message (without this
+// logger's header or fields), the fields as attributes, and the prefix as a
+// "prefix" attribute. Header-less records, such as Separator lines and
+// records forwarded from a child logger, are mirrored as their full line.
+// If the default slog handler is a NewSlogHandler, the records it logs are
+// not mirrored again.
+func (l *Logger) SetSlogMirror(enabled bool) {
+	l.slogMirror.Store(enabled)
+}
+
+func slogLevel(level Level) slog.Level {
+	switch {
+	case level >= ERROR:
+		return slog.LevelError
+	case level == INFO:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}
+
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level >= slog.LevelError:
+		return ERROR
+	case level >= slog.LevelInfo:
+		return INFO
+	default:
+		return DEBUG
+	}
+}
+
+// mirrorMessage returns msg without the field text at spans.
+func mirrorMessage(msg []byte, spans [][2]int) []byte {
+	if len(spans) == 0 {
+		return msg
+	}
+	out := make([]byte, 0, len(msg))
+	start := 0
+	for _, s := range spans {
+		if s[0] < start || s[1] > len(msg) {
+			continue
+		}
+		out = append(out, msg[start:s[0]]...)
+		start = s[1]
+	}
+	return append(out, msg[start:]...)
+}
+
+// mirrorLine mirrors a header-less record p.
+func (l *Logger) mirrorLine(level Level, p []byte) {
+	if l.slogMirror.Load() {
+		l.mirrorToSlog(level, l.now(), bytes.TrimSuffix(p, []byte("\n")), nil)
+	}
+}
+
+func (l *Logger) mirrorToSlog(level Level, t time.Time, msg []byte, fields []field) {
+	ctx := context.WithValue(context.Background(), mirrorKey{}, l)
+	h := slog.Default().Handler()
+	lvl := slogLevel(level)
+	if !h.Enabled(ctx, lvl) {
+		return
+	}
+	r := slog.NewRecord(t, lvl, string(msg), 0)
+	if prefix := l.Prefix(); prefix != "" {
+		r.AddAttrs(slog.String("prefix", prefix))
+	}
+	for _, f := range fields {
+		r.AddAttrs(slog.Any(f.key, f.value))
+	}
+	h.Handle(ctx, r)
+}
+
+type slogHandler struct {
+	l      *Logger
+	attrs  []any
+	prefix string
+}
+
+// NewSlogHandler returns a slog.Handler that writes records to l, with
+// attributes as key=value fields and groups as dotted key prefixes. Records
+// that l itself mirrored to slog are dropped, and records from another
+// Logger's mirror are written without being mirrored again.
+func NewSlogHandler(l *Logger) slog.Handler {
+	return &slogHandler{l: l}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.l.enabled(levelFromSlog(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	origin, mirrored := ctx.Value(mirrorKey{}).(*Logger)
+	if origin == h.l {
+		return nil
+	}
+	kv := append([]any(nil), h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		kv = appendAttr(kv, h.prefix, a)
+		return true
+	})
+	return h.l.output(levelFromSlog(r.Level), r.PC, 0, func(b []byte, e *entry) []byte {
+		e.noMirror = mirrored
+		b = append(b, r.Message...)
+		return h.l.appendFields(b, e, kv)
+	})
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.attrs = append([]any(nil), h.attrs...)
+	for _, a := range attrs {
+		h2.attrs = appendAttr(h2.attrs, h.prefix, a)
+	}
+	return &h2
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+func appendAttr(kv []any, prefix string, a slog.Attr) []any {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			kv = appendAttr(kv, prefix, ga)
+		}
+		return kv
+	}
+	if a.Key == "" {
+		return kv
+	}
+	return append(kv, prefix+a.Key, v.Any())
+}
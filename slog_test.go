@@ -0,0 +1,102 @@
+package mylog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+// setSlogDefault makes h the slog default for the rest of the test.
+func setSlogDefault(t *testing.T, h slog.Handler) {
+	old := slog.Default()
+	slog.SetDefault(slog.New(h))
+	t.Cleanup(func() { slog.SetDefault(old) })
+}
+
+func textHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+}
+
+func TestSlogMirror(t *testing.T) {
+	var mirrored bytes.Buffer
+	setSlogDefault(t, textHandler(&mirrored))
+	l, buf := newTestLogger(LstdFlags)
+	l.SetPrefix("app")
+	l.SetSlogMirror(true)
+
+	l.Event("login", "user", "bob", "n", 3)
+	l.Error("failed", fieldError{})
+	l.Separator()
+	b := l.Batch()
+	b.Add(INFO, "batched", categoryError("billing"))
+	b.Commit()
+	child, _ := newTestLogger(0)
+	child.SetParent(l)
+	child.Info("from child")
+	l.SetSlogMirror(false)
+	l.Info("not mirrored")
+
+	want := `level=INFO msg="" prefix=app event=login user=bob n=3
+level=ERROR msg="failed not found" prefix=app id=42 table=users
+level=INFO msg="========================================" prefix=app
+level=INFO msg="batched charge failed" prefix=app category=billing
+level=INFO msg="[INFO]  from child" prefix=app
+`
+	if got := mirrored.String(); got != want {
+		t.Errorf("mirrored:\n%s\nwant:\n%s", got, want)
+	}
+	if n := len(lines(buf)); n != 6 {
+		t.Errorf("%d records written, want 6", n)
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	l, buf := newTestLogger(Lshortfile)
+	l.SetLevel(INFO)
+	h := NewSlogHandler(l)
+	if h.Enabled(context.Background(), slog.LevelDebug) || !h.Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("Enabled does not follow the logger's level")
+	}
+	s := slog.New(h).With("svc", "api")
+	line := thisLine() + 1
+	s.Info("request", "path", "/", slog.Group("http", "status", 200))
+	s.WithGroup("db").Error("slow", "ms", 30, slog.Group("", "inline", true))
+	s.Debug("hidden")
+	want := fmt.Sprintf("[INFO]  slog_test.go:%d: request svc=api path=/ http.status=200\n", line) +
+		fmt.Sprintf("[ERROR] slog_test.go:%d: slow svc=api db.ms=30 db.inline=true\n", line+1)
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSlogMirrorLoop(t *testing.T) {
+	l, buf := newTestLogger(0)
+	setSlogDefault(t, NewSlogHandler(l))
+	l.SetSlogMirror(true)
+	l.Info("once")
+	slog.Info("via slog", "k", 1)
+	want := "[INFO]  once\n[INFO]  via slog k=1\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestMirrorMessage(t *testing.T) {
+	msg := []byte("event=e a=1 b=2 tail")
+	if got := string(mirrorMessage(msg, [][2]int{{7, 15}})); got != "event=e tail" {
+		t.Errorf("got %q", got)
+	}
+	if got := string(mirrorMessage(msg, nil)); got != string(msg) {
+		t.Errorf("no spans: %q", got)
+	}
+}
@@ -0,0 +1,26 @@
+package mylog
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+type slowWriteAlert struct {
+	threshold time.Duration
+	callback  func(elapsed time.Duration)
+	running   atomic.Bool
+}
+
+// SetSlowWriteThreshold calls callback whenever a single write to the
+// output takes at least threshold. The callback runs in a new goroutine, so
+// it may log through this logger without deadlocking, though doing so sends
+// the warning through the same slow writer. Slow writes while the callback
+// is still running are not reported, so a stuck writer produces one
+// callback rather than one goroutine per write. A nil callback disables it.
+func (l *Logger) SetSlowWriteThreshold(threshold time.Duration, callback func(elapsed time.Duration)) {
+	if callback == nil {
+		l.slowWrite.Store(nil)
+		return
+	}
+	l.slowWrite.Store(&slowWriteAlert{threshold: threshold, callback: callback})
+}
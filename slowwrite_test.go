@@ -0,0 +1,42 @@
+package mylog
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type slowWriter struct {
+	delay time.Duration
+}
+
+func (w slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return len(p), nil
+}
+
+func TestSlowWriteThreshold(t *testing.T) {
+	l := New(slowWriter{20 * time.Millisecond}, "", 0, DEBUG)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var elapsed []time.Duration
+	l.SetSlowWriteThreshold(10*time.Millisecond, func(d time.Duration) {
+		mu.Lock()
+		elapsed = append(elapsed, d)
+		mu.Unlock()
+		<-release
+	})
+	for range 3 {
+		l.Info("slow")
+	}
+	close(release)
+	waitFor(t, func() bool { return !l.slowWrite.Load().running.Load() })
+	mu.Lock()
+	defer mu.Unlock()
+	if len(elapsed) != 1 || elapsed[0] < 10*time.Millisecond {
+		t.Errorf("callbacks = %v, want one of at least 10ms", elapsed)
+	}
+
+	l.SetSlowWriteThreshold(time.Hour, func(time.Duration) { t.Error("callback below threshold") })
+	l.Info("fast enough")
+}
@@ -0,0 +1,48 @@
+package mylog
+
+import (
+	"errors"
+	"os"
+)
+
+// SplitFileWriter is a LevelWriter that sends ERROR and above to one file
+// and everything else to another.
+type SplitFileWriter struct {
+	Info  *os.File
+	Error *os.File
+}
+
+func (w *SplitFileWriter) Write(p []byte) (int, error) {
+	return w.Info.Write(p)
+}
+
+func (w *SplitFileWriter) WriteLevel(level Level, p []byte) (int, error) {
+	if level >= ERROR {
+		return w.Error.Write(p)
+	}
+	return w.Info.Write(p)
+}
+
+func (w *SplitFileWriter) Close() error {
+	return errors.Join(w.Info.Close(), w.Error.Close())
+}
+
+// NewSplitFileLogger returns a logger writing DEBUG and INFO records to
+// infoPath and ERROR records to errorPath, appending to existing files. The
+// logger's Writer is a *SplitFileWriter; close it to release both files.
+func NewSplitFileLogger(infoPath, errorPath string, level Level) (*Logger, error) {
+	info, err := openLogFile(infoPath)
+	if err != nil {
+		return nil, err
+	}
+	errf, err := openLogFile(errorPath)
+	if err != nil {
+		info.Close()
+		return nil, err
+	}
+	return New(&SplitFileWriter{Info: info, Error: errf}, "", LstdFlags, level), nil
+}
+
+func openLogFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+}
@@ -0,0 +1,46 @@
+package mylog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSplitFileLogger(t *testing.T) {
+	dir := t.TempDir()
+	infoPath, errorPath := filepath.Join(dir, "info.log"), filepath.Join(dir, "error.log")
+	l, err := NewSplitFileLogger(infoPath, errorPath, INFO)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.SetFlags(0)
+	l.Info("started")
+	l.Error("failed")
+	l.Info("stopped")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(path string) string {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	if got, want := read(infoPath), "[INFO]  started\n[INFO]  stopped\n"; got != want {
+		t.Errorf("info file = %q, want %q", got, want)
+	}
+	if got, want := read(errorPath), "[ERROR] failed\n"; got != want {
+		t.Errorf("error file = %q, want %q", got, want)
+	}
+}
+
+func TestSplitFileLoggerError(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewSplitFileLogger(filepath.Join(dir, "info.log"), filepath.Join(dir, "missing", "error.log"), INFO)
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("err = %v", err)
+	}
+}
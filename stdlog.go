@@ -0,0 +1,21 @@
+package mylog
+
+import "log"
+
+type stdLoggerWriter struct {
+	std *log.Logger
+}
+
+func (w stdLoggerWriter) Write(p []byte) (int, error) {
+	return len(p), w.std.Output(2, string(p))
+}
+
+// NewFromStdLogger returns a logger whose records are written through
+// std.Output, so they share std's destination and prefix. std adds its own
+// date/time header, so the returned logger starts with no flags and only
+// adds its level label; use SetFlags to add more. std's Lshortfile and
+// Llongfile flags report this package rather than the caller, so use this
+// logger's flags for caller information instead.
+func NewFromStdLogger(std *log.Logger, level Level) *Logger {
+	return New(stdLoggerWriter{std}, "", 0, level)
+}
@@ -0,0 +1,20 @@
+package mylog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestNewFromStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	std := log.New(&buf, "std: ", log.Lmsgprefix)
+	l := NewFromStdLogger(std, INFO)
+	l.Info("hello")
+	l.SetPrefix("app")
+	l.Error("failed")
+	want := "std: [INFO]  hello\nstd: app [ERROR] failed\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
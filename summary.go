@@ -0,0 +1,30 @@
+package mylog
+
+import (
+	"fmt"
+	"time"
+)
+
+// SetCloseSummary makes Close write a final INFO record, regardless of the
+// level filter, with the logger's age, the number of records written at
+// each level and the number dropped by the rate cap or the Pause buffer. A
+// record is counted as written once its write to the output succeeds, so
+// records dropped from the Pause buffer count only as dropped.
+func (l *Logger) SetCloseSummary(enabled bool) {
+	l.summary.Store(enabled)
+}
+
+func (l *Logger) emitSummary() {
+	if !l.summary.Load() || l.summaryDone.Swap(true) || l.isDiscard.Load() {
+		return
+	}
+	age := time.Duration(0)
+	if !l.created.IsZero() {
+		age = time.Since(l.created).Round(time.Millisecond)
+	}
+	l.emit(INFO, l.now(), 0, 3, false, func(b []byte, e *entry) []byte {
+		return fmt.Appendf(b, "summary duration=%s debug=%d info=%d error=%d other=%d dropped=%d\n",
+			age, l.counts[DEBUG].Load(), l.counts[INFO].Load(), l.counts[ERROR].Load(),
+			l.counts[ERROR+1].Load(), l.dropped.Load())
+	})
+}
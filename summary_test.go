@@ -0,0 +1,41 @@
+package mylog
+
+import (
+	"regexp"
+	"testing"
+)
+
+var summaryRecord = regexp.MustCompile(`^\[INFO\]  summary duration=\S+ debug=0 info=2 error=1 other=0 dropped=1\n$`)
+
+func TestCloseSummary(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetCloseSummary(true)
+	l.SetMaxLinesPerSecond(3)
+	l.Info("i")
+	l.Info("i")
+	l.Error("e")
+	l.Error("dropped")
+	buf.Reset()
+	l.SetLevel(ERROR)
+	l.Close()
+	if got := buf.String(); !summaryRecord.MatchString(got) {
+		t.Errorf("summary = %q", got)
+	}
+}
+
+func TestCloseSummaryPauseDropped(t *testing.T) {
+	l, buf := newTestLogger(0)
+	l.SetCloseSummary(true)
+	l.SetPauseLimit(2)
+	l.Pause()
+	l.Info("dropped")
+	l.Info("i")
+	l.Error("e")
+	b := l.Batch()
+	b.Add(INFO, "never committed")
+	l.Close()
+	want := regexp.MustCompile(`^\[INFO\]  i\n\[ERROR\] e\n\[INFO\]  summary duration=\S+ debug=0 info=1 error=1 other=0 dropped=1\n$`)
+	if got := buf.String(); !want.MatchString(got) {
+		t.Errorf("got %q", got)
+	}
+}
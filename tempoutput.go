@@ -0,0 +1,32 @@
+package mylog
+
+import (
+	"io"
+	"slices"
+	"sync"
+)
+
+type tempOutput struct {
+	w io.Writer
+}
+
+// AddTempOutput additionally writes every record to w until the returned
+// function is called. The regular output is unaffected, and errors from w
+// are ignored.
+func (l *Logger) AddTempOutput(w io.Writer) (remove func()) {
+	t := &tempOutput{w: w}
+	l.outMu.Lock()
+	l.temp = append(l.temp, t)
+	l.updateDiscard()
+	l.outMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.outMu.Lock()
+			defer l.outMu.Unlock()
+			l.temp = slices.DeleteFunc(l.temp, func(o *tempOutput) bool { return o == t })
+			l.updateDiscard()
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package mylog
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAddTempOutput(t *testing.T) {
+	l, buf := newTestLogger(0)
+	var extra bytes.Buffer
+	remove := l.AddTempOutput(&extra)
+	l.Info("both")
+	remove()
+	remove()
+	l.Info("main only")
+	if got := buf.String(); got != "[INFO]  both\n[INFO]  main only\n" {
+		t.Errorf("main = %q", got)
+	}
+	if got := extra.String(); got != "[INFO]  both\n" {
+		t.Errorf("temp = %q", got)
+	}
+
+	l.SetOutput(io.Discard)
+	remove = l.AddTempOutput(&extra)
+	defer remove()
+	l.Info("temp only")
+	if !strings.HasSuffix(extra.String(), "[INFO]  temp only\n") {
+		t.Errorf("temp output behind io.Discard: %q", extra.String())
+	}
+}
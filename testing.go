@@ -0,0 +1,45 @@
+package mylog
+
+import (
+	"strings"
+	"sync"
+)
+
+// TB is the subset of testing.TB used by NewTestingLogger, so that this
+// package does not import testing. *testing.T and *testing.B satisfy it.
+type TB interface {
+	Cleanup(func())
+	Helper()
+	Log(args ...any)
+}
+
+type testWriter struct {
+	tb   TB
+	mu   sync.Mutex
+	done bool
+}
+
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.done {
+		w.tb.Helper()
+		w.tb.Log(strings.TrimSuffix(string(p), "\n"))
+	}
+	return len(p), nil
+}
+
+// NewTestingLogger returns a logger that writes each record through tb.Log,
+// so records are attributed to the test and shown only on failure or with
+// -v. It uses Lshortfile because tb.Log reports this package as the caller.
+// Records logged once the test has finished, for example from goroutines it
+// started, are dropped instead of panicking.
+func NewTestingLogger(tb TB, level Level) *Logger {
+	w := &testWriter{tb: tb}
+	tb.Cleanup(func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.done = true
+	})
+	return New(w, "", Lshortfile, level)
+}
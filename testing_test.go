@@ -0,0 +1,36 @@
+package mylog
+
+import (
+	"fmt"
+	"testing"
+)
+
+type fakeTB struct {
+	logs     []string
+	cleanups []func()
+}
+
+func (tb *fakeTB) Cleanup(f func()) { tb.cleanups = append(tb.cleanups, f) }
+func (tb *fakeTB) Helper()          {}
+func (tb *fakeTB) Log(args ...any)  { tb.logs = append(tb.logs, fmt.Sprint(args...)) }
+
+func TestTestingLogger(t *testing.T) {
+	tb := &fakeTB{}
+	l := NewTestingLogger(tb, INFO)
+	line := thisLine() + 1
+	l.Info("hello")
+	l.Debug("hidden")
+	for _, f := range tb.cleanups {
+		f()
+	}
+	l.Info("after the test")
+	want := fmt.Sprintf("[INFO]  testing_test.go:%d: hello", line)
+	if len(tb.logs) != 1 || tb.logs[0] != want {
+		t.Errorf("logs = %q, want [%q]", tb.logs, want)
+	}
+}
+
+func TestTestingLoggerReal(t *testing.T) {
+	l := NewTestingLogger(t, DEBUG)
+	l.Info("shown with go test -v")
+}
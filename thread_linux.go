@@ -0,0 +1,9 @@
+//go:build linux
+
+package mylog
+
+import "syscall"
+
+func threadID() int {
+	return syscall.Gettid()
+}
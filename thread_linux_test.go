@@ -0,0 +1,24 @@
+//go:build linux
+
+package mylog
+
+import (
+	"runtime"
+	"strconv"
+	"syscall"
+	"testing"
+)
+
+func TestThreadID(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	l, buf := newTestLogger(Lthread)
+	l.Info("locked")
+	l.SetHeaderStyle(HeaderKeyValue)
+	l.Info("kv")
+	tid := strconv.Itoa(syscall.Gettid())
+	want := "[INFO]  tid:" + tid + " locked\nlevel=INFO tid=" + tid + " msg=kv\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
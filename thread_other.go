@@ -0,0 +1,8 @@
+//go:build !linux
+
+package mylog
+
+// threadID reports -1 where the OS thread ID is not available.
+func threadID() int {
+	return -1
+}
@@ -0,0 +1,42 @@
+package mylog
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+type levelAction struct {
+	level  Level
+	once   bool
+	fired  atomic.Bool
+	action func(record []byte)
+}
+
+// OnLevelReached registers action to run when a record at level or above is
+// written, for example to capture a profile on the first ERROR. If once is
+// true it runs only for the first such record, otherwise for every one.
+// action runs in its own goroutine so logging never waits on it, and gets
+// its own copy of the formatted record.
+func (l *Logger) OnLevelReached(level Level, once bool, action func(record []byte)) {
+	l.actionsMu.Lock()
+	defer l.actionsMu.Unlock()
+	var actions []*levelAction
+	if old := l.actions.Load(); old != nil {
+		actions = slices.Clone(*old)
+	}
+	actions = append(actions, &levelAction{level: level, once: once, action: action})
+	l.actions.Store(&actions)
+}
+
+func (l *Logger) notifyLevel(level Level, record []byte) {
+	actions := l.actions.Load()
+	if actions == nil {
+		return
+	}
+	for _, a := range *actions {
+		if level < a.level || a.once && a.fired.Swap(true) {
+			continue
+		}
+		go a.action(slices.Clone(record))
+	}
+}
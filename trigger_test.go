@@ -0,0 +1,35 @@
+package mylog
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestOnLevelReached(t *testing.T) {
+	l, _ := newTestLogger(0)
+	var mu sync.Mutex
+	var once, every []string
+	var wg sync.WaitGroup
+	record := func(dst *[]string) func([]byte) {
+		return func(p []byte) {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			*dst = append(*dst, string(p))
+		}
+	}
+	l.OnLevelReached(ERROR, true, record(&once))
+	l.OnLevelReached(INFO, false, record(&every))
+
+	wg.Add(4)
+	l.Info("a")
+	l.Error("b")
+	l.Error("c")
+	wg.Wait()
+	if len(once) != 1 || once[0] != "[ERROR] b\n" {
+		t.Errorf("once = %q", once)
+	}
+	if len(every) != 3 {
+		t.Errorf("every = %q", every)
+	}
+}
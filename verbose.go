@@ -0,0 +1,28 @@
+package mylog
+
+// Verbose logs at DEBUG when its verbosity was within the logger's
+// threshold. It is returned by V.
+type Verbose struct {
+	l  *Logger
+	on bool
+}
+
+// V returns a Verbose whose Info logs only if n is at most the threshold
+// set by SetVerbosity. Verbose records are logged at DEBUG, so they are
+// also subject to the level and level filter, and are stripped with Debug
+// in nodebug builds.
+func (l *Logger) V(n int) Verbose {
+	return Verbose{l: l, on: int32(n) <= l.verbosity.Load()}
+}
+
+// SetVerbosity sets the threshold for V. The default is 0, which enables
+// only V(0) and below.
+func (l *Logger) SetVerbosity(n int) {
+	l.verbosity.Store(int32(n))
+}
+
+// Enabled reports whether Info would write a record, so that costly
+// arguments can be skipped.
+func (v Verbose) Enabled() bool {
+	return debugBuild && v.on && v.l.enabled(DEBUG)
+}
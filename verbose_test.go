@@ -0,0 +1,25 @@
+package mylog
+
+import "testing"
+
+func TestVerbose(t *testing.T) {
+	if !debugBuild {
+		t.Skip("V logs at DEBUG, which the nodebug tag strips")
+	}
+	l, buf := newTestLogger(0)
+	l.V(0).Info("v0")
+	l.V(1).Info("v1 hidden")
+	l.SetVerbosity(2)
+	if !l.V(2).Enabled() || l.V(3).Enabled() {
+		t.Error("Enabled does not follow SetVerbosity")
+	}
+	l.V(2).Info("v2")
+	l.SetLevel(INFO)
+	if l.V(1).Enabled() {
+		t.Error("Enabled while DEBUG is below the level")
+	}
+	l.V(1).Info("filtered by level")
+	if got, want := buf.String(), "[DEBUG] v0\n[DEBUG] v2\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}